@@ -0,0 +1,238 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// canon.go has canonical labeling by partition refinement.
+
+import "sort"
+
+// CanonicalLabeling computes a canonical labeling of a graph.
+//
+// Returned permutation p is suitable for AdjacencyList.Permute.  After
+// permuting by their canonical labelings, isomorphic graphs compare Equal.
+// The graph may be directed or undirected and may have loops and parallel
+// arcs.  Arc counts between nodes are preserved by isomorphism.
+//
+// Also returned are generators of the automorphism group of g.  Each
+// generator is a permutation, in the form used by Permute, that maps g to
+// itself.  Together they generate the full automorphism group, although
+// the set is not necessarily minimal.  For a graph with only the identity
+// automorphism, no generators are returned.
+//
+// The algorithm is a search tree of ordered partitions of the nodes, in the
+// style of McKay's nauty.  Partitions are refined to be equitable, meaning
+// nodes of each cell have the same numbers of arcs to and from each cell.
+// Nodes of non-singleton cells are individualized to branch the search.
+// Leaves of the tree are discrete partitions, each giving a labeling.  The
+// canonical labeling is the leaf labeling giving the greatest permuted
+// graph in a total order of graphs.  Leaves giving equal graphs yield
+// automorphisms, which are used to prune equivalent branches.  Worst case
+// time is exponential but it is fast for most graphs.
+func (g AdjacencyList) CanonicalLabeling() (p []int, generators [][]int) {
+	n := len(g)
+	if n == 0 {
+		return []int{}, nil
+	}
+	t, _ := Directed{g}.Transpose()
+	c := &canon{
+		g:   g,
+		t:   t.AdjacencyList,
+		out: make([]int, n),
+		in:  make([]int, n),
+	}
+	all := make([]NI, n)
+	for i := range all {
+		all[i] = NI(i)
+	}
+	c.search([][]NI{all}, nil)
+	return c.bestLab, c.gens
+}
+
+// canon holds state for CanonicalLabeling.
+type canon struct {
+	g, t    AdjacencyList // graph and transpose
+	out, in []int         // arc counts to and from a splitter cell
+	best    []int         // greatest certificate
+	bestLab []int         // labeling giving best
+	gens    [][]int       // automorphisms found
+}
+
+// search searches the subtree at partition cells, formed by individualizing
+// nodes of prefix.
+func (c *canon) search(cells [][]NI, prefix []NI) {
+	cells = c.refine(cells)
+	// target cell is the first smallest non-singleton cell
+	tc := -1
+	for i, cell := range cells {
+		if len(cell) > 1 && (tc < 0 || len(cell) < len(cells[tc])) {
+			tc = i
+		}
+	}
+	if tc < 0 {
+		c.leaf(cells)
+		return
+	}
+	target := append([]NI{}, cells[tc]...)
+	sort.Slice(target, func(i, j int) bool { return target[i] < target[j] })
+	var tried []NI
+	for _, v := range target {
+		// skip v if an automorphism fixing prefix maps a tried node to v.
+		// orbits are recomputed as automorphisms are found.
+		orb := c.orbits(prefix)
+		equiv := false
+		for _, u := range tried {
+			if orb.find(u) == orb.find(v) {
+				equiv = true
+				break
+			}
+		}
+		if equiv {
+			continue
+		}
+		tried = append(tried, v)
+		// individualize v, placing it in a cell before the rest of its cell
+		rest := make([]NI, 0, len(target)-1)
+		for _, u := range cells[tc] {
+			if u != v {
+				rest = append(rest, u)
+			}
+		}
+		ind := make([][]NI, 0, len(cells)+1)
+		ind = append(ind, cells[:tc]...)
+		ind = append(ind, []NI{v}, rest)
+		ind = append(ind, cells[tc+1:]...)
+		c.search(ind, append(prefix[:len(prefix):len(prefix)], v))
+	}
+}
+
+// orbits returns the orbits of the group generated by the automorphisms
+// found so far that fix each node of prefix.
+func (c *canon) orbits(prefix []NI) disjointSet {
+	ds := newDisjointSet(len(c.g))
+gen:
+	for _, a := range c.gens {
+		for _, v := range prefix {
+			if a[v] != int(v) {
+				continue gen
+			}
+		}
+		for v, w := range a {
+			ds.union(NI(v), NI(w))
+		}
+	}
+	return ds
+}
+
+// refine refines ordered partition cells until it is equitable.  Cells are
+// split by arc counts to and from splitter cells, with the parts ordered by
+// count.  Cell slices are not modified; split cells are replaced by new
+// slices.
+func (c *canon) refine(cells [][]NI) [][]NI {
+	for changed := true; changed && len(cells) < len(c.g); {
+		changed = false
+		for s := 0; s < len(cells); s++ {
+			for _, v := range cells[s] {
+				for _, w := range c.t[v] {
+					c.out[w]++ // arc w->v
+				}
+				for _, w := range c.g[v] {
+					c.in[w]++ // arc v->w
+				}
+			}
+			next := make([][]NI, 0, len(cells))
+			for _, cell := range cells {
+				if len(cell) == 1 || c.uniform(cell) {
+					next = append(next, cell)
+					continue
+				}
+				sp := append([]NI{}, cell...)
+				sort.Slice(sp, func(i, j int) bool { return c.less(sp[i], sp[j]) })
+				i := 0
+				for j := 1; j <= len(sp); j++ {
+					if j == len(sp) || c.less(sp[j-1], sp[j]) {
+						next = append(next, sp[i:j:j])
+						i = j
+					}
+				}
+				changed = true
+			}
+			for v := range c.out {
+				c.out[v], c.in[v] = 0, 0
+			}
+			cells = next
+		}
+	}
+	return cells
+}
+
+// less orders nodes by counts of arcs to and from a splitter cell.
+func (c *canon) less(v, w NI) bool {
+	if c.out[v] != c.out[w] {
+		return c.out[v] < c.out[w]
+	}
+	return c.in[v] < c.in[w]
+}
+
+// uniform returns true if all nodes of cell have the same counts.
+func (c *canon) uniform(cell []NI) bool {
+	v := cell[0]
+	for _, w := range cell[1:] {
+		if c.out[w] != c.out[v] || c.in[w] != c.in[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// leaf handles a discrete partition, comparing the certificate of its
+// labeling to the best so far.
+func (c *canon) leaf(cells [][]NI) {
+	lab := make([]int, len(c.g))
+	for i, cell := range cells {
+		lab[cell[0]] = i
+	}
+	// certificate is the permuted graph, with arc lists sorted, as a
+	// sequence of arc list lengths and arcs.
+	cert := make([]int, 0, len(c.g)+c.g.ArcSize())
+	for _, cell := range cells {
+		to := c.g[cell[0]]
+		cert = append(cert, len(to))
+		row := cert[len(cert):]
+		for _, w := range to {
+			row = append(row, lab[w])
+		}
+		sort.Ints(row)
+		cert = cert[:len(cert)+len(row)]
+	}
+	cmp := 0
+	if c.best == nil {
+		cmp = 1
+	} else {
+		for i, x := range cert {
+			if x != c.best[i] {
+				if x > c.best[i] {
+					cmp = 1
+				} else {
+					cmp = -1
+				}
+				break
+			}
+		}
+	}
+	switch cmp {
+	case 1:
+		c.best, c.bestLab = cert, lab
+	case 0:
+		// automorphism mapping the best leaf to this one
+		inv := make([]int, len(lab))
+		for v, l := range c.bestLab {
+			inv[l] = v
+		}
+		a := make([]int, len(lab))
+		for v, l := range lab {
+			a[v] = inv[l]
+		}
+		c.gens = append(c.gens, a)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleAdjacencyList_CanonicalLabeling() {
+	// Two numberings of a path on four nodes
+	// 0--1--2--3    2--0--3--1
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	h.AddEdge(2, 0)
+	h.AddEdge(0, 3)
+	h.AddEdge(3, 1)
+	fmt.Println("equal:", g.Equal(h.AdjacencyList))
+	pg, gens := g.CanonicalLabeling()
+	ph, _ := h.CanonicalLabeling()
+	fmt.Println("canonical labelings:", pg, ph)
+	fmt.Println("automorphism generators:", gens)
+	g.Permute(pg)
+	h.Permute(ph)
+	fmt.Println("canonical form:", g.AdjacencyList)
+	fmt.Println("equal:", g.Equal(h.AdjacencyList))
+	// Output:
+	// equal: false
+	// canonical labelings: [0 3 2 1] [2 0 1 3]
+	// automorphism generators: [[3 2 1 0]]
+	// canonical form: [[3] [2] [3 1] [0 2]]
+	// equal: true
+}
+
+// TestCanonicalLabeling checks that canonical forms of random graphs and
+// random permutations of them are equal, that canonical forms of
+// non-isomorphic graphs differ, and that automorphism generators generate
+// groups of the size found by Isomorphisms.
+func TestCanonicalLabeling(t *testing.T) {
+	r := rand.New(rand.NewSource(23))
+	canonical := func(a graph.AdjacencyList) graph.AdjacencyList {
+		c, _ := a.Copy()
+		p, _ := c.CanonicalLabeling()
+		c.Permute(p)
+		return c
+	}
+	// groupOrder returns the order of the group generated by gens.
+	groupOrder := func(n int, gens [][]int) int {
+		id := make([]int, n)
+		for i := range id {
+			id[i] = i
+		}
+		seen := map[string]bool{fmt.Sprint(id): true}
+		q := [][]int{id}
+		for len(q) > 0 {
+			p := q[0]
+			q = q[1:]
+			for _, gen := range gens {
+				c := make([]int, n)
+				for i := range c {
+					c[i] = gen[p[i]]
+				}
+				if k := fmt.Sprint(c); !seen[k] {
+					seen[k] = true
+					q = append(q, c)
+				}
+			}
+		}
+		return len(seen)
+	}
+	for i := 0; i < 300; i++ {
+		n := 1 + r.Intn(7)
+		var g, h graph.AdjacencyList
+		directed := i%2 == 1
+		if directed {
+			g = make(graph.AdjacencyList, n)
+			for a := r.Intn(n * n); a > 0; a-- {
+				fr := r.Intn(n)
+				g[fr] = append(g[fr], graph.NI(r.Intn(n)))
+			}
+			h = make(graph.AdjacencyList, n)
+			for a := r.Intn(n * n); a > 0; a-- {
+				fr := r.Intn(n)
+				h[fr] = append(h[fr], graph.NI(r.Intn(n)))
+			}
+		} else {
+			g = graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r).AdjacencyList
+			h = graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r).AdjacencyList
+		}
+		cg := canonical(g)
+		gp, _ := g.Copy()
+		gp.Permute(r.Perm(n))
+		gp.ShuffleArcLists(r)
+		if !canonical(gp).Equal(cg) {
+			t.Fatal("canonical forms of permuted graph differ")
+		}
+		iso := false
+		count := func(m []graph.NI) bool {
+			iso = true
+			return false
+		}
+		if directed {
+			graph.Directed{g}.Isomorphisms(graph.Directed{h}, count)
+		} else {
+			graph.Undirected{g}.Isomorphisms(graph.Undirected{h}, count)
+		}
+		if canonical(h).Equal(cg) != iso {
+			t.Fatal("canonical forms equal:", !iso, "isomorphic:", iso)
+		}
+		_, gens := g.CanonicalLabeling()
+		for _, a := range gens {
+			c, _ := g.Copy()
+			c.Permute(a)
+			if !c.Equal(g) {
+				t.Fatal("generator not an automorphism", a)
+			}
+		}
+		nAut := 0
+		countAut := func(m []graph.NI) bool {
+			nAut++
+			return true
+		}
+		if directed {
+			graph.Directed{g}.Isomorphisms(graph.Directed{g}, countAut)
+		} else {
+			graph.Undirected{g}.Isomorphisms(graph.Undirected{g}, countAut)
+		}
+		if o := groupOrder(n, gens); o != nAut {
+			t.Fatal("group order", o, "want", nAut)
+		}
+	}
+}
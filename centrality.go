@@ -0,0 +1,744 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// centrality.go has node centrality measures.
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+)
+
+// Betweenness computes node and arc betweenness centrality of a directed
+// graph.
+//
+// The betweenness of a node is the sum over ordered pairs of other nodes s, t
+// of the fraction of shortest paths from s to t that pass through the node.
+// The betweenness of an arc is the sum over all ordered pairs s, t of the
+// fraction of shortest paths from s to t that use the arc.  Path length is
+// the number of arcs.
+//
+// If normalize is true, node values are divided by (n-1)(n-2) and arc values
+// by n(n-1), the number of ordered pairs considered, where n is the order
+// of the graph.
+//
+// If k is greater than 0 and less than the order of the graph, values are
+// approximated by summing over paths from k randomly chosen source nodes
+// and scaling by n/k.  Sources are chosen with r, or with the rand package
+// default shared source if r is nil.
+//
+// Returned is a value for each node and a value for each arc, with arc
+// values in a slice parallel to the adjacency list.
+//
+// The algorithm is Brandes' and is O(VE), or O(kE) with sampling.
+func (g Directed) Betweenness(normalize bool, k int, r *rand.Rand) (node []float64, arc [][]float64) {
+	b := newBrandes(g.AdjacencyList, nil)
+	b.run(k, r)
+	b.scale(normalize, false)
+	return b.node, b.arc
+}
+
+// Betweenness computes node and edge betweenness centrality of an
+// undirected graph.
+//
+// The betweenness of a node is the sum over unordered pairs of other nodes
+// s, t of the fraction of shortest paths between s and t that pass through
+// the node.  The betweenness of an edge is the sum over all unordered pairs
+// s, t of the fraction of shortest paths between s and t that use the edge.
+// Path length is the number of edges.
+//
+// If normalize is true, node values are divided by (n-1)(n-2)/2 and edge
+// values by n(n-1)/2, the number of unordered pairs considered, where n is
+// the order of the graph.
+//
+// Argument k is as described for Directed.Betweenness.
+//
+// Returned is a value for each node and a value for each arc, with arc
+// values in a slice parallel to the adjacency list.  The two arcs of an
+// edge have the edge value.
+func (g Undirected) Betweenness(normalize bool, k int, r *rand.Rand) (node []float64, arc [][]float64) {
+	b := newBrandes(g.AdjacencyList, nil)
+	b.run(k, r)
+	b.scale(normalize, true)
+	return b.node, b.arc
+}
+
+// Betweenness computes node and arc betweenness centrality of a labeled
+// directed graph.
+//
+// This is as described for Directed.Betweenness except that path length is
+// the sum of arc weights given by WeightFunc w.  Weights must be positive.
+// If w is nil, path length is the number of arcs.
+//
+// The algorithm is Brandes' and is O(VE + V² log V), or O(kE + kV log V)
+// with sampling.
+func (g LabeledDirected) Betweenness(w WeightFunc, normalize bool, k int, r *rand.Rand) (node []float64, arc [][]float64) {
+	a := g.LabeledAdjacencyList
+	b := newBrandes(a.Unlabeled(), arcWeights(a, w))
+	b.run(k, r)
+	b.scale(normalize, false)
+	return b.node, b.arc
+}
+
+// Betweenness computes node and edge betweenness centrality of a labeled
+// undirected graph.
+//
+// This is as described for Undirected.Betweenness except that path length
+// is the sum of edge weights given by WeightFunc w.  Weights must be
+// positive.  If w is nil, path length is the number of edges.
+func (g LabeledUndirected) Betweenness(w WeightFunc, normalize bool, k int, r *rand.Rand) (node []float64, arc [][]float64) {
+	a := g.LabeledAdjacencyList
+	b := newBrandes(a.Unlabeled(), arcWeights(a, w))
+	b.run(k, r)
+	b.scale(normalize, true)
+	return b.node, b.arc
+}
+
+// arcWeights returns weights of arcs of a in a slice parallel to a, or nil
+// if w is nil.
+func arcWeights(a LabeledAdjacencyList, w WeightFunc) [][]float64 {
+	if w == nil {
+		return nil
+	}
+	wt := make([][]float64, len(a))
+	for fr, to := range a {
+		wt[fr] = make([]float64, len(to))
+		for x, to := range to {
+			wt[fr][x] = w(to.Label)
+		}
+	}
+	return wt
+}
+
+// brandes holds state for Brandes' betweenness algorithm.
+type brandes struct {
+	a       AdjacencyList
+	wt      [][]float64 // arc weights, nil for unweighted
+	node    []float64
+	arc     [][]float64
+	sources int // number of sources used
+	// per source
+	dist  []float64
+	sigma []float64 // number of shortest paths
+	delta []float64 // dependency
+	pred  [][]arcRef
+	order []NI // nodes in order of non-decreasing distance
+	r     []tentResult
+}
+
+// arcRef identifies arc x from node fr.
+type arcRef struct {
+	fr NI
+	x  int
+}
+
+func newBrandes(a AdjacencyList, wt [][]float64) *brandes {
+	n := len(a)
+	b := &brandes{
+		a:     a,
+		wt:    wt,
+		node:  make([]float64, n),
+		arc:   make([][]float64, n),
+		dist:  make([]float64, n),
+		sigma: make([]float64, n),
+		delta: make([]float64, n),
+		pred:  make([][]arcRef, n),
+	}
+	for fr, to := range a {
+		b.arc[fr] = make([]float64, len(to))
+	}
+	if wt != nil {
+		b.r = make([]tentResult, n)
+	}
+	return b
+}
+
+// run accumulates betweenness over all sources or over k random sources.
+func (b *brandes) run(k int, r *rand.Rand) {
+	n := len(b.a)
+	if k <= 0 || k >= n {
+		for s := range b.a {
+			b.source(NI(s))
+		}
+		b.sources = n
+		return
+	}
+	perm := rand.Perm
+	if r != nil {
+		perm = r.Perm
+	}
+	for _, s := range perm(n)[:k] {
+		b.source(NI(s))
+	}
+	b.sources = k
+}
+
+// source accumulates betweenness for shortest paths from s.
+func (b *brandes) source(s NI) {
+	for v := range b.a {
+		b.dist[v] = -1
+		b.sigma[v] = 0
+		b.delta[v] = 0
+		b.pred[v] = b.pred[v][:0]
+	}
+	b.order = b.order[:0]
+	b.dist[s] = 0
+	b.sigma[s] = 1
+	if b.wt == nil {
+		b.order = append(b.order, s)
+		for i := 0; i < len(b.order); i++ {
+			v := b.order[i]
+			for x, w := range b.a[v] {
+				if b.dist[w] < 0 {
+					b.dist[w] = b.dist[v] + 1
+					b.order = append(b.order, w)
+				}
+				if b.dist[w] == b.dist[v]+1 {
+					b.sigma[w] += b.sigma[v]
+					b.pred[w] = append(b.pred[w], arcRef{v, x})
+				}
+			}
+		}
+	} else {
+		for v := range b.r {
+			b.r[v] = tentResult{nx: NI(v)}
+		}
+		var h tent
+		heap.Push(&h, &b.r[s])
+		for len(h) > 0 {
+			c := heap.Pop(&h).(*tentResult)
+			c.done = true
+			v := c.nx
+			b.order = append(b.order, v)
+			for x, w := range b.a[v] {
+				d := b.dist[v] + b.wt[v][x]
+				rw := &b.r[w]
+				switch {
+				case rw.done:
+					continue
+				case b.dist[w] < 0:
+					b.dist[w] = d
+					rw.dist = d
+					heap.Push(&h, rw)
+				case d < b.dist[w]:
+					b.dist[w] = d
+					rw.dist = d
+					heap.Fix(&h, rw.fx)
+					b.sigma[w] = 0
+					b.pred[w] = b.pred[w][:0]
+				case d > b.dist[w]:
+					continue
+				}
+				b.sigma[w] += b.sigma[v]
+				b.pred[w] = append(b.pred[w], arcRef{v, x})
+			}
+		}
+	}
+	// accumulate dependencies in order of non-increasing distance
+	for i := len(b.order) - 1; i >= 0; i-- {
+		w := b.order[i]
+		for _, p := range b.pred[w] {
+			c := b.sigma[p.fr] / b.sigma[w] * (1 + b.delta[w])
+			b.arc[p.fr][p.x] += c
+			b.delta[p.fr] += c
+		}
+		if w != s {
+			b.node[w] += b.delta[w]
+		}
+	}
+}
+
+// scale scales for sampling and normalization.  For undirected graphs,
+// values are halved as each pair is counted in both directions, and
+// values of the two arcs of each edge are combined.
+func (b *brandes) scale(normalize, undirected bool) {
+	n := float64(len(b.a))
+	f := 1.
+	if b.sources > 0 {
+		f = n / float64(b.sources)
+	}
+	fn, fa := f, f
+	if undirected {
+		fn /= 2
+		fa /= 2
+	}
+	if normalize {
+		if n > 2 {
+			fn /= (n - 1) * (n - 2)
+		}
+		if n > 1 {
+			fa /= n * (n - 1)
+		}
+		if undirected {
+			fn *= 2
+			fa *= 2
+		}
+	}
+	for v := range b.node {
+		b.node[v] *= fn
+	}
+	if undirected {
+		// arcs between a pair of nodes all have the same value by symmetry.
+		// for each node, sum over arcs to and from each neighbor, then
+		// distribute.
+		in := make([][]arcRef, len(b.a)) // arcs to each node, except loops
+		for fr, to := range b.a {
+			for x, to := range to {
+				if to != NI(fr) {
+					in[to] = append(in[to], arcRef{NI(fr), x})
+				}
+			}
+		}
+		sum := make([]float64, len(b.a)) // indexed by neighbor
+		cnt := make([]int, len(b.a))
+		arc := make([][]float64, len(b.a))
+		for v, to := range b.a {
+			for x, u := range to {
+				sum[u] += b.arc[v][x]
+				cnt[u]++
+			}
+			for _, p := range in[v] {
+				sum[p.fr] += b.arc[p.fr][p.x]
+				cnt[p.fr]++
+			}
+			arc[v] = make([]float64, len(to))
+			for x, u := range to {
+				// each edge has two arcs, except loops with one
+				arc[v][x] = sum[u] / float64(cnt[u])
+				if u != NI(v) {
+					arc[v][x] *= 2
+				}
+			}
+			for _, u := range to {
+				sum[u], cnt[u] = 0, 0
+			}
+			for _, p := range in[v] {
+				sum[p.fr], cnt[p.fr] = 0, 0
+			}
+		}
+		b.arc = arc
+	}
+	for _, arcs := range b.arc {
+		for x := range arcs {
+			arcs[x] *= fa
+		}
+	}
+}
+
+// Closeness computes closeness centrality of each node of a graph.
+//
+// The closeness of a node is computed from distances from the node to the
+// nodes reachable from it, where distance is the number of arcs of a shortest
+// path.  For a node that reaches r other nodes with a total distance of d,
+// the value is (r/d) * (r/(n-1)) where n is the order of the graph.  This is
+// the formula of Wasserman and Faust, which is meaningful for disconnected
+// graphs.  For a connected undirected graph it reduces to (n-1)/d, the
+// reciprocal of the average distance.  A node reaching no other nodes has
+// closeness 0.
+//
+// For directed graphs, closeness is computed from distances from each node.
+// For closeness by distances to each node, use the transpose.
+//
+// Time complexity is O(V(V + E)).
+//
+// See also Harmonic and LabeledAdjacencyList.Closeness.
+func (g AdjacencyList) Closeness() []float64 {
+	return closeness(len(g), g.distances, false)
+}
+
+// Harmonic computes harmonic centrality of each node of a graph.
+//
+// The harmonic centrality of a node is the sum over other nodes of the
+// reciprocal of the distance from the node, where distance is the number of
+// arcs of a shortest path.  Unreachable nodes contribute 0.  It is thus
+// meaningful for disconnected graphs.
+//
+// For directed graphs, harmonic centrality is computed from distances from
+// each node.  For centrality by distances to each node, use the transpose.
+//
+// Time complexity is O(V(V + E)).
+//
+// See also Closeness and LabeledAdjacencyList.Harmonic.
+func (g AdjacencyList) Harmonic() []float64 {
+	return closeness(len(g), g.distances, true)
+}
+
+// distances calls visit with the distance from s to each node other than s
+// reachable from s.
+func (g AdjacencyList) distances(s NI, visit func(float64)) {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[s] = 0
+	q := []NI{s}
+	for len(q) > 0 {
+		v := q[0]
+		q = q[1:]
+		for _, to := range g[v] {
+			if dist[to] < 0 {
+				dist[to] = dist[v] + 1
+				visit(float64(dist[to]))
+				q = append(q, to)
+			}
+		}
+	}
+}
+
+// Closeness computes closeness centrality of each node of a weighted graph.
+//
+// This is as described for AdjacencyList.Closeness except that distance is
+// the sum of arc weights given by WeightFunc w.  Weights must be
+// non-negative.  Shortest paths are found by Dijkstra's algorithm and time
+// complexity is O(V(V + E) log V).
+func (g LabeledAdjacencyList) Closeness(w WeightFunc) []float64 {
+	return closeness(len(g), g.distances(w), false)
+}
+
+// Harmonic computes harmonic centrality of each node of a weighted graph.
+//
+// This is as described for AdjacencyList.Harmonic except that distance is
+// the sum of arc weights given by WeightFunc w.  Weights must be positive.
+// Shortest paths are found by Dijkstra's algorithm and time complexity is
+// O(V(V + E) log V).
+func (g LabeledAdjacencyList) Harmonic(w WeightFunc) []float64 {
+	return closeness(len(g), g.distances(w), true)
+}
+
+// distances returns a function like AdjacencyList.distances but using
+// arc weights.
+func (g LabeledAdjacencyList) distances(w WeightFunc) func(NI, func(float64)) {
+	return func(s NI, visit func(float64)) {
+		f, _, dist, _ := g.Dijkstra(s, -1, w)
+		for v, p := range f.Paths {
+			if NI(v) != s && p.Len > 0 {
+				visit(dist[v])
+			}
+		}
+	}
+}
+
+// closeness implements Closeness and Harmonic for a graph of order n.
+func closeness(n int, distances func(NI, func(float64)), harmonic bool) []float64 {
+	c := make([]float64, n)
+	for s := range c {
+		var r, sum float64
+		distances(NI(s), func(d float64) {
+			r++
+			if harmonic {
+				sum += 1 / d
+			} else {
+				sum += d
+			}
+		})
+		switch {
+		case harmonic:
+			c[s] = sum
+		case sum > 0:
+			c[s] = r / sum * r / float64(n-1)
+		}
+	}
+	return c
+}
+
+// EigenvectorCentrality computes eigenvector centrality of each node of
+// a directed graph.
+//
+// The centrality of a node is proportional to the sum of centralities of
+// nodes with arcs to it.  The result is an eigenvector of the transposed
+// adjacency matrix for its greatest eigenvalue.  It is computed by power
+// iteration and normalized to unit Euclidean length.
+//
+// Iteration stops when the sum over nodes of absolute changes in centrality
+// is less than n*tol, where n is the order of the graph, or after maxIter
+// iterations.  Returned converged is true if the first condition was met.
+// For a strongly connected graph, iteration converges.  For other graphs
+// it may converge to values that are zero for some nodes.
+//
+// For an undirected graph, use Directed{u.AdjacencyList}.
+func (g Directed) EigenvectorCentrality(tol float64, maxIter int) (c []float64, converged bool) {
+	return eigenvector(len(g.AdjacencyList), g.weightedArcs(), tol, maxIter)
+}
+
+// KatzCentrality computes Katz centrality of each node of a directed graph.
+//
+// The centrality of a node is alpha times the sum of centralities of nodes
+// with arcs to it, plus 1.  Argument alpha is an attenuation factor.  For
+// iteration to converge, alpha must be less than the reciprocal of the
+// greatest eigenvalue of the adjacency matrix.  Centrality is computed by
+// power iteration and normalized to unit Euclidean length.
+//
+// Iteration stops when the sum over nodes of absolute changes in centrality
+// is less than n*tol, where n is the order of the graph, or after maxIter
+// iterations.  Returned converged is true if the first condition was met.
+func (g Directed) KatzCentrality(alpha, tol float64, maxIter int) (c []float64, converged bool) {
+	return katz(len(g.AdjacencyList), g.weightedArcs(), alpha, tol, maxIter)
+}
+
+// HITS computes hub and authority scores of each node of a directed graph
+// by Kleinberg's HITS algorithm.
+//
+// The authority score of a node is proportional to the sum of hub scores of
+// nodes with arcs to it.  The hub score of a node is proportional to the sum
+// of authority scores of nodes at its arcs.  Scores are computed by power
+// iteration and normalized to sum to 1.
+//
+// Iteration stops when the sum over nodes of absolute changes in hub score
+// is less than n*tol, where n is the order of the graph, or after maxIter
+// iterations.  Returned converged is true if the first condition was met.
+func (g Directed) HITS(tol float64, maxIter int) (hub, authority []float64, converged bool) {
+	return hits(len(g.AdjacencyList), g.weightedArcs(), tol, maxIter)
+}
+
+// EigenvectorCentrality computes eigenvector centrality of each node of
+// a weighted directed graph.
+//
+// This is as described for Directed.EigenvectorCentrality except that
+// arcs are weighted by WeightFunc w.  Weights should be positive.  If w is
+// nil, arcs have weight 1.
+func (g LabeledDirected) EigenvectorCentrality(w WeightFunc, tol float64, maxIter int) (c []float64, converged bool) {
+	return eigenvector(len(g.LabeledAdjacencyList), g.weightedArcs(w),
+		tol, maxIter)
+}
+
+// KatzCentrality computes Katz centrality of each node of a weighted
+// directed graph.
+//
+// This is as described for Directed.KatzCentrality except that arcs are
+// weighted by WeightFunc w.  Weights should be positive.  If w is nil, arcs
+// have weight 1.
+func (g LabeledDirected) KatzCentrality(w WeightFunc, alpha, tol float64, maxIter int) (c []float64, converged bool) {
+	return katz(len(g.LabeledAdjacencyList), g.weightedArcs(w),
+		alpha, tol, maxIter)
+}
+
+// HITS computes hub and authority scores of each node of a weighted
+// directed graph.
+//
+// This is as described for Directed.HITS except that arcs are weighted by
+// WeightFunc w.  Weights should be positive.  If w is nil, arcs have
+// weight 1.
+func (g LabeledDirected) HITS(w WeightFunc, tol float64, maxIter int) (hub, authority []float64, converged bool) {
+	return hits(len(g.LabeledAdjacencyList), g.weightedArcs(w), tol, maxIter)
+}
+
+// PersonalizedPageRank computes PageRank with a personalization vector,
+// handling nodes without out arcs.
+//
+// Argument d is a damping factor as described for PageRank.  Argument p is
+// a personalization, or teleport, vector giving a non-negative weight for
+// each node.  A random surfer jumps with probability 1-d to a node chosen in
+// proportion to p.  If p is nil or sums to zero, jumps are to nodes chosen
+// uniformly, giving ordinary PageRank.  A random surfer at a dangling node,
+// a node without out arcs, also jumps to a node chosen in proportion to p.
+// Scores thus sum to the order of the graph.
+//
+// Iteration stops when the sum over nodes of absolute changes in score is
+// less than n*tol, where n is the order of the graph, or after maxIter
+// iterations.  Returned is the score for each node, the number of
+// iterations used, and converged true if the first condition was met.
+func (g Directed) PersonalizedPageRank(d float64, p []float64, tol float64, maxIter int) (pr []float64, iterations int, converged bool) {
+	return pageRank(len(g.AdjacencyList), g.weightedArcs(), d, p, tol, maxIter)
+}
+
+// PersonalizedPageRank computes PageRank of a weighted graph with a
+// personalization vector, handling nodes without out arcs.
+//
+// This is as described for Directed.PersonalizedPageRank except that a
+// random surfer follows an out arc with probability proportional to its
+// weight given by WeightFunc w.  Weights must be non-negative.  A node with
+// zero total out arc weight is a dangling node.  If w is nil, arcs have
+// weight 1.
+func (g LabeledDirected) PersonalizedPageRank(w WeightFunc, d float64, p []float64, tol float64, maxIter int) (pr []float64, iterations int, converged bool) {
+	return pageRank(len(g.LabeledAdjacencyList), g.weightedArcs(w),
+		d, p, tol, maxIter)
+}
+
+// pageRank implements PersonalizedPageRank.
+func pageRank(n int, wa []weightedArc, d float64, p []float64, tol float64, maxIter int) (pr []float64, iterations int, converged bool) {
+	// q is teleport probability, normalized from p.
+	q := make([]float64, n)
+	copy(q, p)
+	if sum(q) > 0 {
+		scaleTo(q, sum)
+	} else {
+		for v := range q {
+			q[v] = 1 / float64(n)
+		}
+	}
+	outW := make([]float64, n)
+	for _, a := range wa {
+		outW[a.fr] += a.w
+	}
+	pr = make([]float64, n)
+	for v := range pr {
+		pr[v] = 1
+	}
+	next := make([]float64, n)
+	converged = n == 0
+	for iterations < maxIter && !converged {
+		iterations++
+		var dangling float64
+		for v, w := range outW {
+			if w == 0 {
+				dangling += pr[v]
+			}
+		}
+		t := (1-d)*float64(n) + d*dangling
+		for v := range next {
+			next[v] = t * q[v]
+		}
+		for _, a := range wa {
+			if a.w != 0 {
+				next[a.to] += d * pr[a.fr] * a.w / outW[a.fr]
+			}
+		}
+		pr, next = next, pr
+		converged = change(pr, next) < float64(n)*tol
+	}
+	return
+}
+
+// weightedArc is an arc with a weight, used for power iteration.
+type weightedArc struct {
+	fr, to NI
+	w      float64
+}
+
+func (g Directed) weightedArcs() (wa []weightedArc) {
+	for fr, to := range g.AdjacencyList {
+		for _, to := range to {
+			wa = append(wa, weightedArc{NI(fr), to, 1})
+		}
+	}
+	return
+}
+
+func (g LabeledDirected) weightedArcs(w WeightFunc) (wa []weightedArc) {
+	for fr, to := range g.LabeledAdjacencyList {
+		for _, to := range to {
+			x := 1.
+			if w != nil {
+				x = w(to.Label)
+			}
+			wa = append(wa, weightedArc{NI(fr), to.To, x})
+		}
+	}
+	return
+}
+
+// change returns the sum of absolute differences of x and y.
+func change(x, y []float64) (d float64) {
+	for i, xi := range x {
+		d += math.Abs(xi - y[i])
+	}
+	return
+}
+
+// scaleTo scales x so that f(x) is 1, unless it is 0.
+func scaleTo(x []float64, f func([]float64) float64) {
+	if s := f(x); s != 0 {
+		for i := range x {
+			x[i] /= s
+		}
+	}
+}
+
+func euclidean(x []float64) (s float64) {
+	for _, xi := range x {
+		s += xi * xi
+	}
+	return math.Sqrt(s)
+}
+
+func sum(x []float64) (s float64) {
+	for _, xi := range x {
+		s += xi
+	}
+	return
+}
+
+func maxOf(x []float64) (m float64) {
+	for _, xi := range x {
+		m = math.Max(m, xi)
+	}
+	return
+}
+
+// eigenvector implements EigenvectorCentrality.  It iterates x + Aᵀx, which
+// has the same eigenvectors as Aᵀx but converges for periodic graphs.
+func eigenvector(n int, wa []weightedArc, tol float64, maxIter int) ([]float64, bool) {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for i := 0; i < maxIter; i++ {
+		copy(next, x)
+		for _, a := range wa {
+			next[a.to] += x[a.fr] * a.w
+		}
+		scaleTo(next, euclidean)
+		x, next = next, x
+		if change(x, next) < float64(n)*tol {
+			return x, true
+		}
+	}
+	return x, n == 0
+}
+
+// katz implements KatzCentrality.
+func katz(n int, wa []weightedArc, alpha, tol float64, maxIter int) ([]float64, bool) {
+	x := make([]float64, n)
+	next := make([]float64, n)
+	converged := n == 0
+	for i := 0; i < maxIter; i++ {
+		for v := range next {
+			next[v] = 1
+		}
+		for _, a := range wa {
+			next[a.to] += alpha * x[a.fr] * a.w
+		}
+		x, next = next, x
+		if change(x, next) < float64(n)*tol {
+			converged = true
+			break
+		}
+	}
+	scaleTo(x, euclidean)
+	return x, converged
+}
+
+// hits implements HITS.
+func hits(n int, wa []weightedArc, tol float64, maxIter int) (hub, auth []float64, converged bool) {
+	hub = make([]float64, n)
+	auth = make([]float64, n)
+	last := make([]float64, n)
+	for i := range hub {
+		hub[i] = 1 / float64(n)
+	}
+	converged = n == 0
+	for i := 0; i < maxIter; i++ {
+		copy(last, hub)
+		for v := range auth {
+			auth[v], hub[v] = 0, 0
+		}
+		for _, a := range wa {
+			auth[a.to] += last[a.fr] * a.w
+		}
+		for _, a := range wa {
+			hub[a.fr] += auth[a.to] * a.w
+		}
+		scaleTo(hub, maxOf)
+		scaleTo(auth, maxOf)
+		if change(hub, last) < float64(n)*tol {
+			converged = true
+			break
+		}
+	}
+	scaleTo(hub, sum)
+	scaleTo(auth, sum)
+	return
+}
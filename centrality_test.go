@@ -0,0 +1,744 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleDirected_Betweenness() {
+	// 0-->1-->2
+	//     |   ^
+	//     v   |
+	//     3-->4
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {2, 3},
+		3: {4},
+		4: {2},
+	}}
+	node, arc := g.Betweenness(false, 0, nil)
+	fmt.Println(node)
+	fmt.Println(arc)
+	// Output:
+	// [0 3 0 2 1]
+	// [[4] [2 4] [] [4] [2]]
+}
+
+func ExampleUndirected_Betweenness() {
+	// Two triangles joined at node 2.
+	// 0   3
+	// |\ /|
+	// | 2 |
+	// |/ \|
+	// 1   4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	node, arc := g.Betweenness(false, 0, nil)
+	fmt.Println(node)
+	fmt.Println(arc)
+	node, _ = g.Betweenness(true, 0, nil)
+	fmt.Println(node)
+	// Output:
+	// [0 0 4 0 0]
+	// [[1 3] [1 3] [3 3 3 3] [3 1] [3 1]]
+	// [0 0 0.6666666666666666 0 0]
+}
+
+func ExampleLabeledDirected_Betweenness() {
+	// Labels are arc weights.
+	//      1      1
+	//  0----->1----->2
+	//   \            ^
+	//    \----->3----/
+	//       1      2
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 1}, {To: 3, Label: 1}},
+		1: {{To: 2, Label: 1}},
+		3: {{To: 2, Label: 2}},
+	}}
+	w := func(l graph.LI) float64 { return float64(l) }
+	node, _ := g.Betweenness(w, false, 0, nil)
+	fmt.Println("weighted:", node)
+	node, _ = g.Betweenness(nil, false, 0, nil)
+	fmt.Println("unweighted:", node)
+	// Output:
+	// weighted: [0 1 0 0]
+	// unweighted: [0 0.5 0 0.5]
+}
+
+func ExampleLabeledUndirected_Betweenness() {
+	// A square with one heavy edge.  Labels are edge weights.
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 1)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	g.AddEdge(graph.Edge{2, 3}, 1)
+	g.AddEdge(graph.Edge{3, 0}, 5)
+	node, arc := g.Betweenness(func(l graph.LI) float64 { return float64(l) },
+		false, 0, nil)
+	fmt.Println(node)
+	fmt.Println(arc)
+	// Output:
+	// [0 2 2 0]
+	// [[3 0] [3 4] [4 3] [3 0]]
+}
+
+// bruteBetweenness computes betweenness from all pairs distances and path
+// counts.  wt is a weight for each arc.
+func bruteBetweenness(a graph.AdjacencyList, wt [][]float64) (node []float64, arc [][]float64) {
+	n := len(a)
+	inf := math.Inf(1)
+	d := make([][]float64, n)
+	for i := range d {
+		d[i] = make([]float64, n)
+		for j := range d[i] {
+			d[i][j] = inf
+		}
+		d[i][i] = 0
+	}
+	for fr, to := range a {
+		for x, to := range to {
+			d[fr][to] = math.Min(d[fr][to], wt[fr][x])
+		}
+	}
+	for k := range d {
+		for i := range d {
+			for j := range d {
+				d[i][j] = math.Min(d[i][j], d[i][k]+d[k][j])
+			}
+		}
+	}
+	// sigma[s][t] by increasing distance from s
+	sigma := make([][]float64, n)
+	for s := range sigma {
+		sigma[s] = make([]float64, n)
+		sigma[s][s] = 1
+		done := make([]bool, n)
+		done[s] = true
+		for {
+			t := -1
+			for v := range done {
+				if !done[v] && d[s][v] < inf && (t < 0 || d[s][v] < d[s][t]) {
+					t = v
+				}
+			}
+			if t < 0 {
+				break
+			}
+			done[t] = true
+			for u, to := range a {
+				for x, to := range to {
+					if int(to) == t && u != t && d[s][u]+wt[u][x] == d[s][t] {
+						sigma[s][t] += sigma[s][u]
+					}
+				}
+			}
+		}
+	}
+	node = make([]float64, n)
+	arc = make([][]float64, n)
+	for u, to := range a {
+		arc[u] = make([]float64, len(to))
+	}
+	for s := range a {
+		for t := range a {
+			if s == t || d[s][t] == inf {
+				continue
+			}
+			for v := range a {
+				if v != s && v != t && d[s][v]+d[v][t] == d[s][t] {
+					node[v] += sigma[s][v] * sigma[v][t] / sigma[s][t]
+				}
+			}
+			for u, to := range a {
+				for x, w := range to {
+					if d[s][u]+wt[u][x]+d[w][t] == d[s][t] {
+						arc[u][x] += sigma[s][u] * sigma[w][t] / sigma[s][t]
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// TestBetweenness compares Betweenness to a brute force computation on
+// small random graphs.
+func TestBetweenness(t *testing.T) {
+	r := rand.New(rand.NewSource(31))
+	close := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	check := func(what string, node, wantNode []float64, arc, wantArc [][]float64, f float64) {
+		for v := range node {
+			if !close(node[v], wantNode[v]*f) {
+				t.Fatal(what, "node", node, "want", wantNode)
+			}
+		}
+		for u := range arc {
+			for x := range arc[u] {
+				if !close(arc[u][x], wantArc[u][x]*f) {
+					t.Fatal(what, "arc", arc, "want", wantArc)
+				}
+			}
+		}
+	}
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(10)
+		// labeled directed, weights 1 to 3
+		g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, n)}
+		for a := r.Intn(n * 3); a > 0; a-- {
+			fr := r.Intn(n)
+			g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+				graph.Half{graph.NI(r.Intn(n)), graph.LI(1 + r.Intn(3))})
+		}
+		w := func(l graph.LI) float64 { return float64(l) }
+		wt := make([][]float64, n)
+		unit := make([][]float64, n)
+		for fr, to := range g.LabeledAdjacencyList {
+			for _, to := range to {
+				wt[fr] = append(wt[fr], w(to.Label))
+				unit[fr] = append(unit[fr], 1)
+			}
+		}
+		u := g.Unlabeled()
+		wantNode, wantArc := bruteBetweenness(g.Unlabeled().AdjacencyList, wt)
+		node, arc := g.Betweenness(w, false, 0, nil)
+		check("weighted", node, wantNode, arc, wantArc, 1)
+		wantNode, wantArc = bruteBetweenness(u.AdjacencyList, unit)
+		node, arc = u.Betweenness(false, 0, nil)
+		check("unweighted", node, wantNode, arc, wantArc, 1)
+		if n > 2 {
+			node, arc = u.Betweenness(true, n, r)
+			fn := 1 / float64((n-1)*(n-2))
+			fa := 1 / float64(n*(n-1))
+			for v := range node {
+				if !close(node[v], wantNode[v]*fn) {
+					t.Fatal("normalized node", node)
+				}
+			}
+			for v := range arc {
+				for x := range arc[v] {
+					if !close(arc[v][x], wantArc[v][x]*fa) {
+						t.Fatal("normalized arc", arc)
+					}
+				}
+			}
+		}
+		// undirected, halved
+		ug := graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r)
+		unit = make([][]float64, n)
+		for fr, to := range ug.AdjacencyList {
+			for range to {
+				unit[fr] = append(unit[fr], 1)
+			}
+		}
+		wantNode, wantArc = bruteBetweenness(ug.AdjacencyList, unit)
+		node, arc = ug.Betweenness(false, 0, nil)
+		// in a simple undirected graph, the edge value is the sum of the
+		// two directed arc values, halved.
+		for fr, to := range ug.AdjacencyList {
+			for x, to := range to {
+				_, y := ug.HasArc(to, graph.NI(fr))
+				if !close(arc[fr][x], (wantArc[fr][x]+wantArc[to][y])/2) {
+					t.Fatal("undirected arc", arc, "want", wantArc)
+				}
+			}
+		}
+		for v := range node {
+			if !close(node[v], wantNode[v]/2) {
+				t.Fatal("undirected node", node, "want", wantNode)
+			}
+		}
+		// doubling each edge halves each arc value, a loop has value 0.
+		var mg graph.Undirected
+		mg.AdjacencyList = make(graph.AdjacencyList, n)
+		ug.Edges(func(e graph.Edge) {
+			mg.AddEdge(e.N1, e.N2)
+			mg.AddEdge(e.N1, e.N2)
+		})
+		mg.AddEdge(0, 0)
+		mNode, mArc := mg.Betweenness(false, 0, nil)
+		for fr, to := range mg.AdjacencyList {
+			for x, to := range to {
+				want := 0.
+				if to != graph.NI(fr) {
+					_, y := ug.HasArc(graph.NI(fr), to)
+					want = arc[fr][y] / 2
+				}
+				if !close(mArc[fr][x], want) {
+					t.Fatal("multigraph arc", mArc, "want", arc)
+				}
+			}
+		}
+		for v := range mNode {
+			if !close(mNode[v], node[v]) {
+				t.Fatal("multigraph node", mNode, "want", node)
+			}
+		}
+		// sampling is exact with k = n, approximate otherwise
+		if n > 1 {
+			k := 1 + r.Intn(n-1)
+			node, _ = ug.Betweenness(false, k, r)
+			sum := 0.
+			for _, x := range node {
+				sum += x
+			}
+			if math.IsNaN(sum) || sum < 0 {
+				t.Fatal("sampled", node)
+			}
+		}
+	}
+}
+
+func ExampleAdjacencyList_Closeness() {
+	// A path on three nodes and a separate edge.
+	// 0--1--2  3--4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 4)
+	fmt.Printf("%.3f\n", g.Closeness())
+	// Output:
+	// [0.333 0.500 0.333 0.250 0.250]
+}
+
+func ExampleAdjacencyList_Harmonic() {
+	// 0--1--2  3--4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 4)
+	fmt.Println(g.Harmonic())
+	// Output:
+	// [1.5 2 1.5 1 1]
+}
+
+func ExampleLabeledAdjacencyList_Closeness() {
+	// Labels are edge weights.
+	//    2     1
+	// 0-----1-----2
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 2)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Printf("%.3f\n", g.Closeness(w))
+	// Output:
+	// [0.400 0.667 0.500]
+}
+
+func ExampleLabeledAdjacencyList_Harmonic() {
+	//    2     1
+	// 0-----1-----2
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 2)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Printf("%.3f\n", g.Harmonic(w))
+	// Output:
+	// [0.833 1.500 1.333]
+}
+
+// TestCloseness compares Closeness and Harmonic to values computed from
+// Floyd-Warshall distances on small random directed graphs.
+func TestCloseness(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(10)
+		g := make(graph.LabeledAdjacencyList, n)
+		for a := r.Intn(n * 2); a > 0; a-- {
+			fr := r.Intn(n)
+			g[fr] = append(g[fr],
+				graph.Half{graph.NI(r.Intn(n)), graph.LI(1 + r.Intn(3))})
+		}
+		w := func(l graph.LI) float64 { return float64(l) }
+		for _, weighted := range []bool{false, true} {
+			inf := math.Inf(1)
+			d := make([][]float64, n)
+			for i := range d {
+				d[i] = make([]float64, n)
+				for j := range d[i] {
+					d[i][j] = inf
+				}
+				d[i][i] = 0
+			}
+			for fr, to := range g {
+				for _, to := range to {
+					x := 1.
+					if weighted {
+						x = w(to.Label)
+					}
+					d[fr][to.To] = math.Min(d[fr][to.To], x)
+				}
+			}
+			for k := range d {
+				for i := range d {
+					for j := range d {
+						d[i][j] = math.Min(d[i][j], d[i][k]+d[k][j])
+					}
+				}
+			}
+			var c, h []float64
+			if weighted {
+				c, h = g.Closeness(w), g.Harmonic(w)
+			} else {
+				u := g.Unlabeled()
+				c, h = u.Closeness(), u.Harmonic()
+			}
+			for s := range d {
+				var reached, sum, harm float64
+				for t, x := range d[s] {
+					if t != s && x < inf {
+						reached++
+						sum += x
+						harm += 1 / x
+					}
+				}
+				want := 0.
+				if sum > 0 {
+					want = reached / sum * reached / float64(n-1)
+				}
+				if math.Abs(c[s]-want) > 1e-9 {
+					t.Fatal("closeness", c, "node", s, "want", want)
+				}
+				if math.Abs(h[s]-harm) > 1e-9 {
+					t.Fatal("harmonic", h, "node", s, "want", harm)
+				}
+			}
+		}
+	}
+}
+
+func ExampleDirected_EigenvectorCentrality() {
+	// A star, with arcs in both directions.
+	//    1
+	//    |
+	// 2--0--3
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(0, 3)
+	c, ok := graph.Directed{g.AdjacencyList}.EigenvectorCentrality(1e-9, 100)
+	fmt.Printf("%.3f %t\n", c, ok)
+	// Output:
+	// [0.707 0.408 0.408 0.408] true
+}
+
+func ExampleDirected_KatzCentrality() {
+	// 0->1->2
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {2},
+		2: {},
+	}}
+	c, ok := g.KatzCentrality(.5, 1e-9, 100)
+	fmt.Printf("%.3f %t\n", c, ok)
+	// Output:
+	// [0.398 0.597 0.697] true
+}
+
+func ExampleDirected_HITS() {
+	// 0  1
+	// | /|
+	// vv v
+	// 2  3
+	g := graph.Directed{graph.AdjacencyList{
+		0: {2},
+		1: {2, 3},
+		3: {},
+	}}
+	hub, auth, ok := g.HITS(1e-9, 100)
+	fmt.Printf("hub:       %.3f\n", hub)
+	fmt.Printf("authority: %.3f\n", auth)
+	fmt.Println(ok)
+	// Output:
+	// hub:       [0.382 0.618 0.000 0.000]
+	// authority: [0.000 0.000 0.618 0.382]
+	// true
+}
+
+func ExampleLabeledDirected_EigenvectorCentrality() {
+	// Labels are arc weights.
+	//    1
+	//   -->
+	// 0     1
+	//   <--
+	//    4
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 1}},
+		1: {{To: 0, Label: 4}},
+	}}
+	w := func(l graph.LI) float64 { return float64(l) }
+	c, ok := g.EigenvectorCentrality(w, 1e-9, 100)
+	fmt.Printf("%.3f %t\n", c, ok)
+	// Output:
+	// [0.894 0.447] true
+}
+
+// TestPowerIteration checks that EigenvectorCentrality, KatzCentrality,
+// and HITS results satisfy their defining equations on random strongly
+// connected weighted graphs.
+func TestPowerIteration(t *testing.T) {
+	r := rand.New(rand.NewSource(41))
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(12)
+		g := make(graph.LabeledAdjacencyList, n)
+		// a cycle through all nodes makes the graph strongly connected.
+		for fr := range g {
+			g[fr] = append(g[fr], graph.Half{graph.NI((fr + 1) % n),
+				graph.LI(1 + r.Intn(5))})
+		}
+		for a := r.Intn(2 * n); a > 0; a-- {
+			fr := r.Intn(n)
+			g[fr] = append(g[fr], graph.Half{graph.NI(r.Intn(n)),
+				graph.LI(1 + r.Intn(5))})
+		}
+		w := func(l graph.LI) float64 { return float64(l) }
+		weighted := i%2 == 0
+		if !weighted {
+			w = nil
+		}
+		wt := func(l graph.LI) float64 {
+			if w == nil {
+				return 1
+			}
+			return w(l)
+		}
+		// in returns Aᵀx, out returns Ax.
+		in := func(x []float64) []float64 {
+			y := make([]float64, n)
+			for fr, to := range g {
+				for _, h := range to {
+					y[h.To] += wt(h.Label) * x[fr]
+				}
+			}
+			return y
+		}
+		out := func(x []float64) []float64 {
+			y := make([]float64, n)
+			for fr, to := range g {
+				for _, h := range to {
+					y[fr] += wt(h.Label) * x[h.To]
+				}
+			}
+			return y
+		}
+		// proportional checks that y = kx for some k.
+		proportional := func(y, x []float64) bool {
+			var sy, sx float64
+			for v := range x {
+				sy += y[v]
+				sx += x[v]
+			}
+			for v := range x {
+				if math.Abs(y[v]*sx-x[v]*sy) > 1e-6*sx*sy {
+					return false
+				}
+			}
+			return true
+		}
+		ld := graph.LabeledDirected{g}
+		var c []float64
+		var ok bool
+		if weighted {
+			c, ok = ld.EigenvectorCentrality(w, 1e-12, 10000)
+		} else {
+			c, ok = graph.Directed{g.Unlabeled()}.EigenvectorCentrality(1e-12, 10000)
+		}
+		if !ok {
+			t.Fatal("eigenvector not converged")
+		}
+		if !proportional(in(c), c) {
+			t.Fatal("not an eigenvector:", c)
+		}
+		// alpha less than 1/(max weighted in-degree) guarantees convergence.
+		ones := make([]float64, n)
+		for v := range ones {
+			ones[v] = 1
+		}
+		var maxIn float64
+		for _, x := range in(ones) {
+			maxIn = math.Max(maxIn, x)
+		}
+		alpha := .9 / maxIn
+		if weighted {
+			c, ok = ld.KatzCentrality(w, alpha, 1e-12, 10000)
+		} else {
+			c, ok = graph.Directed{g.Unlabeled()}.KatzCentrality(alpha, 1e-12, 10000)
+		}
+		if !ok {
+			t.Fatal("Katz not converged")
+		}
+		// c - alpha Aᵀc is a constant vector.
+		ac := in(c)
+		k := c[0] - alpha*ac[0]
+		for v := range c {
+			if math.Abs(c[v]-alpha*ac[v]-k) > 1e-9 {
+				t.Fatal("Katz", c)
+			}
+		}
+		var hub, auth []float64
+		if weighted {
+			hub, auth, ok = ld.HITS(w, 1e-12, 10000)
+		} else {
+			hub, auth, ok = graph.Directed{g.Unlabeled()}.HITS(1e-12, 10000)
+		}
+		if !ok {
+			t.Fatal("HITS not converged")
+		}
+		if !proportional(in(hub), auth) || !proportional(out(auth), hub) {
+			t.Fatal("HITS", hub, auth)
+		}
+	}
+}
+
+func ExampleDirected_PersonalizedPageRank() {
+	// Node 2 is a dangling node.
+	//  0---->1
+	//   \   /
+	//    v v
+	//     2
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {},
+	}}
+	pr, it, ok := g.PersonalizedPageRank(.85, nil, 1e-9, 100)
+	fmt.Printf("%.3f  sum %.3f\n", pr, pr[0]+pr[1]+pr[2])
+	fmt.Println(it, ok)
+	// personalized to node 0
+	pr, _, _ = g.PersonalizedPageRank(.85, []float64{1, 0, 0}, 1e-9, 100)
+	fmt.Printf("%.3f\n", pr)
+	// Output:
+	// [0.593 0.845 1.563]  sum 3.000
+	// 20 true
+	// [1.357 0.577 1.067]
+}
+
+func ExampleLabeledDirected_PersonalizedPageRank() {
+	// Labels are arc weights.
+	//      3
+	//  0------>1
+	//  ^\      |
+	//  1 \1    |1
+	//  |  v    |
+	//  |--2<---/
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 3}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 1}},
+		2: {{To: 0, Label: 1}},
+	}}
+	w := func(l graph.LI) float64 { return float64(l) }
+	pr, _, _ := g.PersonalizedPageRank(w, .85, nil, 1e-9, 100)
+	fmt.Printf("%.3f\n", pr)
+	// Output:
+	// [1.076 0.836 1.089]
+}
+
+// TestPersonalizedPageRank checks that PersonalizedPageRank results sum to
+// the order of the graph and satisfy the PageRank equation, and that it
+// agrees with PageRank on graphs without dangling nodes.
+func TestPersonalizedPageRank(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+	const d = .85
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(12)
+		g := make(graph.LabeledAdjacencyList, n)
+		for a := r.Intn(3 * n); a > 0; a-- {
+			fr := r.Intn(n)
+			g[fr] = append(g[fr], graph.Half{graph.NI(r.Intn(n)),
+				graph.LI(1 + r.Intn(5))})
+		}
+		w := func(l graph.LI) float64 { return float64(l) }
+		var p []float64
+		if i%3 > 0 {
+			p = make([]float64, n)
+			for v := range p {
+				p[v] = float64(r.Intn(3))
+			}
+			p[r.Intn(n)] = 1
+		}
+		weighted := i%2 == 0
+		var pr []float64
+		var ok bool
+		if weighted {
+			pr, _, ok = graph.LabeledDirected{g}.PersonalizedPageRank(w, d, p, 1e-12, 1000)
+		} else {
+			pr, _, ok = graph.Directed{g.Unlabeled()}.PersonalizedPageRank(d, p, 1e-12, 1000)
+			w = func(graph.LI) float64 { return 1 }
+		}
+		if !ok {
+			t.Fatal("not converged")
+		}
+		q := make([]float64, n)
+		var qs, sum float64
+		for v := range q {
+			q[v] = 1
+			if p != nil {
+				q[v] = p[v]
+			}
+			qs += q[v]
+			sum += pr[v]
+		}
+		if math.Abs(sum-float64(n)) > 1e-9 {
+			t.Fatal("sum", sum, "want", n)
+		}
+		// pr = (1-d)n q + d(Mᵀ pr + dangling q)
+		want := make([]float64, n)
+		var dangling float64
+		for fr, to := range g {
+			var ow float64
+			for _, h := range to {
+				ow += w(h.Label)
+			}
+			if ow == 0 {
+				dangling += pr[fr]
+			}
+			for _, h := range to {
+				want[h.To] += d * pr[fr] * w(h.Label) / ow
+			}
+		}
+		for v := range want {
+			want[v] += ((1-d)*float64(n) + d*dangling) * q[v] / qs
+			if math.Abs(pr[v]-want[v]) > 1e-9 {
+				t.Fatal("PageRank", pr, "want", want)
+			}
+		}
+		if !weighted && p == nil && dangling == 0 {
+			old := graph.Directed{g.Unlabeled()}.PageRank(d, 500)
+			for v := range old {
+				if math.Abs(pr[v]-old[v]) > 1e-9 {
+					t.Fatal("PageRank", pr, "PageRank", old)
+				}
+			}
+		}
+	}
+	// a personalization vector summing to zero is taken as uniform
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {2},
+		2: {0, 1},
+		3: {},
+	}}
+	pz, _, _ := g.PersonalizedPageRank(d, make([]float64, 4), 1e-12, 1000)
+	pn, _, _ := g.PersonalizedPageRank(d, nil, 1e-12, 1000)
+	var sum float64
+	for v := range pz {
+		sum += pz[v]
+		if pz[v] != pn[v] {
+			t.Fatal("zero personalization", pz, "want", pn)
+		}
+	}
+	if math.Abs(sum-4) > 1e-9 {
+		t.Fatal("zero personalization sum", sum, "want", 4)
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// chordal.go has chordal graph recognition and algorithms for chordal graphs.
+
+import "sort"
+
+// LexBFS computes a lexicographic breadth first search ordering of the
+// nodes of an undirected graph.
+//
+// Returned is the order in which nodes are visited.  The search starts at
+// node 0 and continues through all connected components.
+//
+// The implementation uses partition refinement and is O(V + E).
+//
+// The reverse of a LexBFS ordering is a perfect elimination ordering if
+// and only if the graph is chordal.
+//
+// See also MaximumCardinalitySearch and Chordal.
+func (g Undirected) LexBFS() []NI {
+	a := g.AdjacencyList
+	n := len(a)
+	// seq holds nodes partitioned into contiguous cells.  Cells are
+	// identified by their start index in seq, which advances as nodes are
+	// moved out of the cell.
+	seq := make([]NI, n)
+	pos := make([]int, n)
+	cellOf := make([]int, n)
+	var start []int
+	if n > 0 {
+		start = []int{0}
+	}
+	for i := range seq {
+		seq[i] = NI(i)
+		pos[i] = i
+	}
+	split := []int{0} // stamp for cells split by the current node
+	splitTo := []int{0}
+	seen := make([]int, n) // stamp for neighbors, for parallel edges
+	for i := 0; i < n; i++ {
+		v := seq[i]
+		start[cellOf[v]]++
+		for _, w := range a[v] {
+			if pos[w] <= i || seen[w] == i+1 {
+				continue
+			}
+			seen[w] = i + 1
+			c := cellOf[w]
+			if split[c] != i+1 {
+				split[c] = i + 1
+				splitTo[c] = len(start)
+				start = append(start, start[c])
+				split = append(split, 0)
+				splitTo = append(splitTo, 0)
+			}
+			// swap w to the front of cell c and move the cell boundary
+			x := start[c]
+			u := seq[x]
+			seq[x], seq[pos[w]] = w, u
+			pos[u], pos[w] = pos[w], x
+			cellOf[w] = splitTo[c]
+			start[c]++
+		}
+	}
+	return seq
+}
+
+// MaximumCardinalitySearch computes a maximum cardinality search ordering
+// of the nodes of an undirected graph.
+//
+// At each step the search visits an unvisited node with the greatest number
+// of visited neighbors.  Returned is the order in which nodes are visited.
+// The search starts at node 0 and continues through all connected
+// components.
+//
+// Time complexity is O(V + E).
+//
+// The reverse of a maximum cardinality search ordering is a perfect
+// elimination ordering if and only if the graph is chordal.
+//
+// See also LexBFS and Chordal.
+func (g Undirected) MaximumCardinalitySearch() []NI {
+	a := g.AdjacencyList
+	n := len(a)
+	order := make([]NI, 0, n)
+	weight := make([]int, n)
+	visited := make([]bool, n)
+	seen := make([]int, n) // stamp for neighbors, for parallel edges
+	// buckets by weight.  entries are lazily deleted.
+	bucket := make([][]NI, n+1)
+	for v := n - 1; v >= 0; v-- {
+		bucket[0] = append(bucket[0], NI(v))
+	}
+	j := 0 // max weight of unvisited nodes
+	for len(order) < n {
+		b := bucket[j]
+		if len(b) == 0 {
+			j--
+			continue
+		}
+		v := b[len(b)-1]
+		bucket[j] = b[:len(b)-1]
+		if visited[v] || weight[v] != j {
+			continue
+		}
+		visited[v] = true
+		order = append(order, v)
+		for _, w := range a[v] {
+			if visited[w] || seen[w] == len(order) {
+				continue
+			}
+			seen[w] = len(order)
+			weight[w]++
+			bucket[weight[w]] = append(bucket[weight[w]], w)
+			if weight[w] > j {
+				j = weight[w]
+			}
+		}
+	}
+	return order
+}
+
+// Chordal tests whether an undirected graph is chordal.
+//
+// A graph is chordal if every cycle of four or more nodes has a chord,
+// an edge joining two nodes of the cycle that are not adjacent in the cycle.
+//
+// If g is chordal, chordal is true and peo is a perfect elimination ordering
+// of the nodes of g.  In a perfect elimination ordering, the neighbors of
+// each node that follow it in the ordering form a clique.  If g is not
+// chordal, chordal is false and cycle is a chordless cycle of four or more
+// nodes, as a list of nodes in cycle order.
+//
+// Loops and parallel edges are ignored.
+//
+// The perfect elimination ordering is the reverse of the ordering computed
+// by MaximumCardinalitySearch.  Testing the ordering is O(V + VΔ) where Δ is
+// the maximum degree.  Finding a chordless cycle may take longer.
+//
+// See also ChordalColoring, ChordalMaxClique, and ChordalCliqueTree.
+func (g Undirected) Chordal() (chordal bool, peo []NI, cycle []NI) {
+	a := g.AdjacencyList
+	n := len(a)
+	peo = g.MaximumCardinalitySearch()
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		peo[i], peo[j] = peo[j], peo[i]
+	}
+	pos := make([]int, n)
+	for i, v := range peo {
+		pos[v] = i
+	}
+	// For each node v, the follower f is the first node after v among its
+	// neighbors.  Other following neighbors must be adjacent to f.
+	mark := make([]int, n)
+	for i, v := range peo {
+		f := NI(-1)
+		for _, w := range a[v] {
+			if pos[w] > i && (f < 0 || pos[w] < pos[f]) {
+				f = w
+			}
+		}
+		if f < 0 {
+			continue
+		}
+		for _, x := range a[f] {
+			mark[x] = i + 1
+		}
+		mark[f] = i + 1
+		for _, w := range a[v] {
+			if pos[w] > i && mark[w] != i+1 {
+				return false, nil, g.chordlessCycle(pos)
+			}
+		}
+	}
+	return true, peo, nil
+}
+
+// chordlessCycle finds a chordless cycle in a graph known to be
+// non-chordal.  Argument pos is the position of each node in an elimination
+// ordering.
+//
+// For each node v and pair of following neighbors x, y that are not
+// adjacent, it searches for a shortest path from x to y avoiding other
+// neighbors of v.  Such a path with v forms a chordless cycle.  A search
+// must succeed for the first node of any chordless cycle in the ordering.
+func (g Undirected) chordlessCycle(pos []int) []NI {
+	a := g.AdjacencyList
+	n := len(a)
+	order := make([]NI, n)
+	for v, p := range pos {
+		order[p] = NI(v)
+	}
+	nbr := make([]int, n)     // stamp for neighbors of v
+	adj := make([]int, n)     // stamp for neighbors of x
+	reached := make([]int, n) // stamp for nodes reached in search
+	from := make([]NI, n)
+	adjStamp, searchStamp := 0, 0
+	for i, v := range order {
+		for _, w := range a[v] {
+			nbr[w] = i + 1
+		}
+		later := g.followers(v, i, pos)
+		for xi, x := range later {
+			adjStamp++
+			for _, w := range a[x] {
+				adj[w] = adjStamp
+			}
+			for _, y := range later[xi+1:] {
+				if adj[y] == adjStamp {
+					continue
+				}
+				// breadth first search from x to y avoiding v and
+				// neighbors of v other than x and y.
+				searchStamp++
+				reached[x] = searchStamp
+				from[x] = -1
+				q := []NI{x}
+			search:
+				for len(q) > 0 {
+					u := q[0]
+					q = q[1:]
+					for _, w := range a[u] {
+						if reached[w] == searchStamp || w == v ||
+							nbr[w] == i+1 && w != y {
+							continue
+						}
+						reached[w] = searchStamp
+						from[w] = u
+						if w == y {
+							break search
+						}
+						q = append(q, w)
+					}
+				}
+				if reached[y] == searchStamp {
+					c := []NI{v}
+					for w := y; w >= 0; w = from[w] {
+						c = append(c, w)
+					}
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ChordalMaxClique finds a maximum clique of a chordal graph.
+//
+// Argument peo must be a perfect elimination ordering of g, as returned
+// by Chordal.
+//
+// Returned is a list of nodes of a maximum clique, in numerical order.
+//
+// Time complexity is O(V + E).
+func (g Undirected) ChordalMaxClique(peo []NI) []NI {
+	a := g.AdjacencyList
+	pos := make([]int, len(a))
+	for i, v := range peo {
+		pos[v] = i
+	}
+	var mc []NI
+	for i, v := range peo {
+		c := g.followers(v, i, pos)
+		if len(c)+1 > len(mc) {
+			mc = append(c, v)
+		}
+	}
+	sort.Slice(mc, func(i, j int) bool { return mc[i] < mc[j] })
+	return mc
+}
+
+// followers returns the distinct neighbors of node v that follow v at
+// position i in an ordering.
+func (g Undirected) followers(v NI, i int, pos []int) (f []NI) {
+	for _, w := range g.AdjacencyList[v] {
+		if pos[w] > i {
+			f = append(f, w)
+		}
+	}
+	// remove duplicates from parallel edges
+	if len(f) > 1 {
+		sort.Slice(f, func(i, j int) bool { return f[i] < f[j] })
+		u := f[:1]
+		for _, w := range f[1:] {
+			if w != u[len(u)-1] {
+				u = append(u, w)
+			}
+		}
+		f = u
+	}
+	return
+}
+
+// ChordalColoring colors a chordal graph with the minimum number of colors.
+//
+// Argument peo must be a perfect elimination ordering of g, as returned
+// by Chordal.
+//
+// Returned is a color for each node, numbered from 0, and the number of
+// colors used.  The number of colors is the size of a maximum clique.
+//
+// Nodes are colored by GreedyColoring in the reverse of peo.
+// Time complexity is O(V + E).
+func (g Undirected) ChordalColoring(peo []NI) (color []int, nColors int) {
+	order := make([]NI, len(peo))
+	for i, v := range peo {
+		order[len(peo)-1-i] = v
+	}
+	return g.GreedyColoring(order)
+}
+
+// ChordalCliqueTree constructs a clique tree of a chordal graph.
+//
+// Argument peo must be a perfect elimination ordering of g, as returned
+// by Chordal.
+//
+// Returned cliques lists the maximal cliques of g, each with nodes in
+// numerical order.  FromList f has a node for each clique, indexing cliques.
+// It represents a tree for a connected graph, or a forest for a disconnected
+// graph.  For each node of g, the cliques containing the node form a
+// subtree.  The intersection of a clique with its parent is a minimal
+// separator of g.  Leaves and MaxLen of f are set.
+func (g Undirected) ChordalCliqueTree(peo []NI) (cliques [][]NI, f FromList) {
+	a := g.AdjacencyList
+	n := len(a)
+	pos := make([]int, n)
+	for i, v := range peo {
+		pos[v] = i
+	}
+	fol := make([][]NI, n) // following neighbors of each node
+	first := make([]NI, n) // follower, first following neighbor
+	cl := make([]int, n)   // clique containing v and its followers
+	sub := make([]int, n)  // clique of a child u with fol[u] = {v}+fol[v]
+	for i := range sub {
+		sub[i] = -1
+	}
+	for i, v := range peo {
+		fol[v] = g.followers(v, i, pos)
+		first[v] = -1
+		for _, w := range fol[v] {
+			if first[v] < 0 || pos[w] < pos[first[v]] {
+				first[v] = w
+			}
+		}
+	}
+	var reps []NI // representative node of each clique
+	for _, v := range peo {
+		if c := sub[v]; c >= 0 {
+			cl[v] = c // {v} + followers is not maximal
+		} else {
+			cl[v] = len(reps)
+			reps = append(reps, v)
+		}
+		if p := first[v]; p >= 0 && len(fol[v]) == len(fol[p])+1 {
+			sub[p] = cl[v]
+		}
+	}
+	cliques = make([][]NI, len(reps))
+	f = NewFromList(len(reps))
+	for c, v := range reps {
+		k := append([]NI{v}, fol[v]...)
+		sort.Slice(k, func(i, j int) bool { return k[i] < k[j] })
+		cliques[c] = k
+		// the parent is the clique of the first follower not in this
+		// clique.
+		p := first[v]
+		for p >= 0 && cl[p] == c {
+			p = first[p]
+		}
+		f.Paths[c].From = -1
+		if p >= 0 {
+			f.Paths[c].From = NI(cl[p])
+		}
+	}
+	f.RecalcLeaves()
+	f.RecalcLen()
+	return
+}
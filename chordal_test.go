@@ -0,0 +1,284 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/soniakeys/bits"
+	"github.com/soniakeys/graph"
+)
+
+// chordalExample returns an example chordal graph.
+func chordalExample() graph.Undirected {
+	// 0--4--5-
+	//    |\ | \
+	//    3--2--1
+	var g graph.Undirected
+	g.AddEdge(0, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(4, 3)
+	g.AddEdge(4, 2)
+	g.AddEdge(3, 2)
+	g.AddEdge(5, 2)
+	g.AddEdge(5, 1)
+	g.AddEdge(2, 1)
+	return g
+}
+
+func ExampleUndirected_LexBFS() {
+	g := chordalExample()
+	fmt.Println(g.LexBFS())
+	// Output:
+	// [0 4 5 2 3 1]
+}
+
+func ExampleUndirected_MaximumCardinalitySearch() {
+	g := chordalExample()
+	fmt.Println(g.MaximumCardinalitySearch())
+	// Output:
+	// [0 4 2 5 1 3]
+}
+
+func ExampleUndirected_Chordal() {
+	g := chordalExample()
+	chordal, peo, _ := g.Chordal()
+	fmt.Println("chordal:", chordal)
+	fmt.Println("perfect elimination ordering:", peo)
+	// Output:
+	// chordal: true
+	// perfect elimination ordering: [3 1 5 2 4 0]
+}
+
+func ExampleUndirected_Chordal_cycle() {
+	// 0--4--5-
+	//    |  | \
+	//    3--2--1
+	var g graph.Undirected
+	g.AddEdge(0, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(4, 3)
+	g.AddEdge(3, 2)
+	g.AddEdge(5, 2)
+	g.AddEdge(5, 1)
+	g.AddEdge(2, 1)
+	chordal, _, cycle := g.Chordal()
+	fmt.Println("chordal:", chordal)
+	fmt.Println("chordless cycle:", cycle)
+	// Output:
+	// chordal: false
+	// chordless cycle: [5 4 3 2]
+}
+
+func ExampleUndirected_ChordalMaxClique() {
+	g := chordalExample()
+	_, peo, _ := g.Chordal()
+	fmt.Println(g.ChordalMaxClique(peo))
+	// Output:
+	// [2 3 4]
+}
+
+func ExampleUndirected_ChordalColoring() {
+	g := chordalExample()
+	_, peo, _ := g.Chordal()
+	color, n := g.ChordalColoring(peo)
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 3 colors
+	// [0 1 0 2 1 2]
+}
+
+func ExampleUndirected_ChordalCliqueTree() {
+	g := chordalExample()
+	_, peo, _ := g.Chordal()
+	cliques, f := g.ChordalCliqueTree(peo)
+	for c, k := range cliques {
+		fmt.Println(c, k, "parent", f.Paths[c].From)
+	}
+	// Output:
+	// 0 [2 3 4] parent 2
+	// 1 [1 2 5] parent 2
+	// 2 [2 4 5] parent 3
+	// 3 [0 4] parent -1
+}
+
+// randomChordal returns a random chordal graph, constructed by eliminating
+// nodes of a random graph in random order and adding fill edges.
+func randomChordal(n int, r *rand.Rand) graph.Undirected {
+	g := graph.GnmUndirected(n, r.Intn(n+1), r)
+	adj := make([]bits.Bits, n)
+	for i := range adj {
+		adj[i] = bits.New(n)
+	}
+	g.Edges(func(e graph.Edge) {
+		adj[e.N1].SetBit(int(e.N2), 1)
+		adj[e.N2].SetBit(int(e.N1), 1)
+	})
+	done := bits.New(n)
+	for _, v := range r.Perm(n) {
+		done.SetBit(v, 1)
+		var later []int
+		adj[v].IterateOnes(func(w int) bool {
+			if done.Bit(w) == 0 {
+				later = append(later, w)
+			}
+			return true
+		})
+		for i, x := range later {
+			for _, y := range later[i+1:] {
+				if adj[x].Bit(y) == 0 {
+					adj[x].SetBit(y, 1)
+					adj[y].SetBit(x, 1)
+					g.AddEdge(graph.NI(x), graph.NI(y))
+				}
+			}
+		}
+	}
+	return g
+}
+
+// TestChordal checks Chordal certificates on random graphs and checks
+// chordal algorithms on random chordal graphs.
+func TestChordal(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	isPEO := func(g graph.Undirected, peo []graph.NI) bool {
+		pos := make([]int, g.Order())
+		for i, v := range peo {
+			pos[v] = i
+		}
+		for i, v := range peo {
+			for _, x := range g.AdjacencyList[v] {
+				for _, y := range g.AdjacencyList[v] {
+					if x != y && pos[x] > i && pos[y] > i {
+						if has, _, _ := g.HasEdge(x, y); !has {
+							return false
+						}
+					}
+				}
+			}
+		}
+		return true
+	}
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(15)
+		g := graph.GnmUndirected(n, r.Intn(2*n), r)
+		lex := g.LexBFS()
+		for i, j := 0, len(lex)-1; i < j; i, j = i+1, j-1 {
+			lex[i], lex[j] = lex[j], lex[i]
+		}
+		chordal, peo, cycle := g.Chordal()
+		if chordal != isPEO(g, lex) {
+			t.Fatal("LexBFS disagrees with Chordal")
+		}
+		if chordal {
+			if !isPEO(g, peo) {
+				t.Fatal("not a PEO", peo)
+			}
+			continue
+		}
+		// check chordless cycle
+		if len(cycle) < 4 {
+			t.Fatal("cycle too short", cycle)
+		}
+		for x, u := range cycle {
+			for y, v := range cycle[x+1:] {
+				y += x + 1
+				adjacent := y == x+1 || x == 0 && y == len(cycle)-1
+				if has, _, _ := g.HasEdge(u, v); has != adjacent {
+					t.Fatal("not a chordless cycle", cycle)
+				}
+			}
+		}
+	}
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(15)
+		g := randomChordal(n, r)
+		chordal, peo, _ := g.Chordal()
+		if !chordal {
+			t.Fatal("random chordal graph not chordal")
+		}
+		// maximal cliques by Bron Kerbosch
+		var want [][]graph.NI
+		max := 0
+		g.BronKerbosch1(func(c bits.Bits) bool {
+			var k []graph.NI
+			c.IterateOnes(func(n int) bool {
+				k = append(k, graph.NI(n))
+				return true
+			})
+			want = append(want, k)
+			if len(k) > max {
+				max = len(k)
+			}
+			return true
+		})
+		if mc := g.ChordalMaxClique(peo); len(mc) != max {
+			t.Fatal("ChordalMaxClique", mc, "want size", max)
+		}
+		color, nc := g.ChordalColoring(peo)
+		if nc != max {
+			t.Fatal("ChordalColoring", nc, "colors, want", max)
+		}
+		for fr, to := range g.AdjacencyList {
+			for _, to := range to {
+				if color[fr] == color[to] {
+					t.Fatal("ChordalColoring not proper")
+				}
+			}
+		}
+		cliques, f := g.ChordalCliqueTree(peo)
+		key := func(k []graph.NI) string { return fmt.Sprint(k) }
+		var got, exp []string
+		for _, k := range cliques {
+			got = append(got, key(k))
+		}
+		for _, k := range want {
+			exp = append(exp, key(k))
+		}
+		sort.Strings(got)
+		sort.Strings(exp)
+		if fmt.Sprint(got) != fmt.Sprint(exp) {
+			t.Fatal("cliques", got, "want", exp)
+		}
+		// a tree for each connected component
+		_, gnc := g.ConnectedComponentInts()
+		roots := 0
+		for _, e := range f.Paths {
+			if e.From < 0 {
+				roots++
+			}
+		}
+		if roots != gnc {
+			t.Fatal("clique tree roots", roots, "components", gnc)
+		}
+		// cliques containing each node form a subtree, so exactly one of
+		// them has a parent not containing the node, or no parent.
+		contains := func(c int, v graph.NI) bool {
+			for _, w := range cliques[c] {
+				if w == v {
+					return true
+				}
+			}
+			return false
+		}
+		for v := graph.NI(0); int(v) < n; v++ {
+			tops := 0
+			for c := range cliques {
+				if !contains(c, v) {
+					continue
+				}
+				if p := f.Paths[c].From; p < 0 || !contains(int(p), v) {
+					tops++
+				}
+			}
+			if tops != 1 {
+				t.Fatal("cliques containing", v, "not a subtree")
+			}
+		}
+	}
+}
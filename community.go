@@ -0,0 +1,509 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// community.go has community detection.
+
+import "math/rand"
+
+// Louvain finds communities of an undirected graph by the Louvain method of
+// modularity optimization.
+//
+// The method alternates two phases.  In the first, nodes are visited in
+// order and each is moved to the neighboring community giving the greatest
+// increase in modularity.  Neighbors of moved nodes are queued to be visited
+// again, until no move increases modularity.  In the second, each community
+// is contracted to a single node, with edge weights summed.  The phases are
+// repeated on the contracted graph until no node moves.
+//
+// Returned community has a community ID for each node of g.  IDs are
+// numbered from 0, in order of the first node of each community.  Returned
+// q is the modularity of the partition.  Returned levels has the partition
+// found at each level of contraction, with IDs numbered the same way.  The
+// first level is the partition found by moving the nodes of g, the last is
+// community.  There is always at least one level.
+//
+// Loops are counted as described for Modularity.
+//
+// See also Modularity and LabeledUndirected.Louvain.
+func (g Undirected) Louvain() (community []int, q float64, levels [][]int) {
+	return g.modGraph().louvain()
+}
+
+// Louvain finds communities of a weighted undirected graph by the Louvain
+// method of modularity optimization.
+//
+// This is as described for Undirected.Louvain except that edges are
+// weighted by WeightFunc w.  Weights must be non-negative.  If w is nil,
+// edges have weight 1.
+func (g LabeledUndirected) Louvain(w WeightFunc) (community []int, q float64, levels [][]int) {
+	return g.modGraph(w).louvain()
+}
+
+// Modularity computes the modularity of a partition of the nodes of an
+// undirected graph into communities.
+//
+// Argument community has a community ID for each node of g.  IDs can be any
+// non-negative int values.  Memory used is proportional to the greatest ID.
+//
+// Modularity is the fraction of edges within communities minus the fraction
+// expected if edges were placed at random preserving node degrees.  With
+// A the adjacency matrix, k node degrees, and m the number of edges, it is
+// the sum over pairs of nodes i, j in the same community of
+// (A[i][j] - k[i]*k[j]/2m) / 2m.  A loop counts 2 in A and in the node
+// degree.  Values range from -1/2 to 1.  A graph without edges has
+// modularity 0.
+func (g Undirected) Modularity(community []int) float64 {
+	return g.modGraph().modularity(community)
+}
+
+// Modularity computes the modularity of a partition of the nodes of a
+// weighted undirected graph into communities.
+//
+// This is as described for Undirected.Modularity except that edges are
+// weighted by WeightFunc w.  Weights must be non-negative.  If w is nil,
+// edges have weight 1.
+func (g LabeledUndirected) Modularity(w WeightFunc, community []int) float64 {
+	return g.modGraph(w).modularity(community)
+}
+
+// modGraph is a weighted undirected graph used for modularity computations.
+type modGraph struct {
+	adj  [][]modHalf // arcs to other nodes, two for each edge
+	self []float64   // weight of loops, counted twice
+	k    []float64   // node strength, the weighted degree
+	m2   float64     // sum of strengths, twice the total edge weight
+}
+
+type modHalf struct {
+	to int
+	w  float64
+}
+
+func newModGraph(n int) *modGraph {
+	return &modGraph{
+		adj:  make([][]modHalf, n),
+		self: make([]float64, n),
+		k:    make([]float64, n),
+	}
+}
+
+func (g Undirected) modGraph() *modGraph {
+	a := g.AdjacencyList
+	mg := newModGraph(len(a))
+	for fr, to := range a {
+		for _, to := range to {
+			mg.addArc(fr, int(to), 1)
+		}
+	}
+	mg.strength()
+	return mg
+}
+
+func (g LabeledUndirected) modGraph(w WeightFunc) *modGraph {
+	a := g.LabeledAdjacencyList
+	mg := newModGraph(len(a))
+	for fr, to := range a {
+		for _, to := range to {
+			x := 1.
+			if w != nil {
+				x = w(to.Label)
+			}
+			mg.addArc(fr, int(to.To), x)
+		}
+	}
+	mg.strength()
+	return mg
+}
+
+// addArc adds an arc of an undirected graph, where a loop is a single arc.
+func (mg *modGraph) addArc(fr, to int, w float64) {
+	if fr == to {
+		mg.self[fr] += 2 * w
+	} else {
+		mg.adj[fr] = append(mg.adj[fr], modHalf{to, w})
+	}
+}
+
+// strength computes k and m2.
+func (mg *modGraph) strength() {
+	mg.m2 = 0
+	for v, arcs := range mg.adj {
+		k := mg.self[v]
+		for _, h := range arcs {
+			k += h.w
+		}
+		mg.k[v] = k
+		mg.m2 += k
+	}
+}
+
+func (mg *modGraph) modularity(community []int) float64 {
+	if mg.m2 == 0 {
+		return 0
+	}
+	nc := 0
+	for _, c := range community {
+		if c >= nc {
+			nc = c + 1
+		}
+	}
+	var in float64
+	tot := make([]float64, nc) // community strength
+	for v, c := range community {
+		tot[c] += mg.k[v]
+		in += mg.self[v]
+		for _, h := range mg.adj[v] {
+			if community[h.to] == c {
+				in += h.w
+			}
+		}
+	}
+	var sq float64
+	for _, t := range tot {
+		sq += t * t
+	}
+	return in/mg.m2 - sq/(mg.m2*mg.m2)
+}
+
+func (mg *modGraph) louvain() (community []int, q float64, levels [][]int) {
+	g0 := mg
+	community = make([]int, len(mg.adj))
+	for v := range community {
+		community[v] = v
+	}
+	for {
+		comm, nc, moved := mg.move()
+		if !moved && len(levels) > 0 {
+			break
+		}
+		for v, c := range community {
+			community[v] = comm[c]
+		}
+		levels = append(levels, append([]int{}, community...))
+		if !moved {
+			break
+		}
+		mg = mg.contract(comm, nc)
+	}
+	return community, g0.modularity(community), levels
+}
+
+// move is the first phase of the Louvain method.  It returns a community ID
+// for each node, numbered from 0 in order of the first node of each
+// community, and the number of communities.
+func (mg *modGraph) move() (comm []int, nc int, moved bool) {
+	n := len(mg.adj)
+	comm = make([]int, n)
+	tot := make([]float64, n) // community strength
+	for v := range comm {
+		comm[v] = v
+		tot[v] = mg.k[v]
+	}
+	wc := make([]float64, n) // weight from the current node to communities
+	touched := make([]bool, n)
+	var nbr []int // communities with touched set
+	// queue of nodes to visit, a ring buffer initially holding all nodes.
+	q := make([]int, n)
+	inQ := make([]bool, n)
+	for v := range q {
+		q[v] = v
+		inQ[v] = true
+	}
+	head, qLen := 0, n
+	if mg.m2 == 0 {
+		qLen = 0
+	}
+	for ; qLen > 0; qLen-- {
+		v := q[head]
+		head = (head + 1) % n
+		inQ[v] = false
+		c := comm[v]
+		k := mg.k[v]
+		tot[c] -= k
+		nbr = append(nbr[:0], c)
+		touched[c] = true
+		for _, h := range mg.adj[v] {
+			c2 := comm[h.to]
+			if !touched[c2] {
+				touched[c2] = true
+				nbr = append(nbr, c2)
+			}
+			wc[c2] += h.w
+		}
+		// gain in modularity from adding v to a community, times m2/2
+		gain := func(c int) float64 { return wc[c] - tot[c]*k/mg.m2 }
+		best, bestGain := c, gain(c)
+		for _, c2 := range nbr[1:] {
+			// small tolerance prevents moves on rounding differences
+			if g := gain(c2); g > bestGain+1e-12*k {
+				best, bestGain = c2, g
+			}
+		}
+		for _, c2 := range nbr {
+			wc[c2] = 0
+			touched[c2] = false
+		}
+		tot[best] += k
+		if best == c {
+			continue
+		}
+		comm[v] = best
+		moved = true
+		// neighbors outside the new community may now gain by moving
+		for _, h := range mg.adj[v] {
+			if u := h.to; comm[u] != best && !inQ[u] {
+				q[(head+qLen-1)%n] = u
+				inQ[u] = true
+				qLen++
+			}
+		}
+	}
+	// renumber
+	id := make([]int, n)
+	for i := range id {
+		id[i] = -1
+	}
+	for v, c := range comm {
+		if id[c] < 0 {
+			id[c] = nc
+			nc++
+		}
+		comm[v] = id[c]
+	}
+	return
+}
+
+// contract is the second phase of the Louvain method.  It returns a graph
+// with a node for each of nc communities.
+func (mg *modGraph) contract(comm []int, nc int) *modGraph {
+	cg := newModGraph(nc)
+	// nodes of each community, in node order, by counting sort
+	start := make([]int, nc+1)
+	for _, c := range comm {
+		start[c+1]++
+	}
+	for c := 1; c <= nc; c++ {
+		start[c] += start[c-1]
+	}
+	next := append([]int{}, start[:nc]...)
+	members := make([]int, len(comm))
+	for v, c := range comm {
+		members[next[c]] = v
+		next[c]++
+	}
+	wc := make([]float64, nc) // weight from the current community
+	touched := make([]bool, nc)
+	var nbr []int // communities with touched set
+	for c := 0; c < nc; c++ {
+		for _, v := range members[start[c]:start[c+1]] {
+			cg.self[c] += mg.self[v]
+			for _, h := range mg.adj[v] {
+				c2 := comm[h.to]
+				if c2 == c {
+					// both arcs of the edge are counted, as for loops.
+					cg.self[c] += h.w
+					continue
+				}
+				if !touched[c2] {
+					touched[c2] = true
+					nbr = append(nbr, c2)
+				}
+				wc[c2] += h.w
+			}
+		}
+		for _, c2 := range nbr {
+			cg.adj[c] = append(cg.adj[c], modHalf{c2, wc[c2]})
+			wc[c2] = 0
+			touched[c2] = false
+		}
+		nbr = nbr[:0]
+	}
+	cg.strength()
+	return cg
+}
+
+// AsyncLabelPropagation finds communities of an undirected graph by
+// asynchronous label propagation.
+//
+// Each node starts with a label of its own.  In each round, nodes are
+// visited in random order and each takes the label most common among its
+// neighbors.  Ties are broken randomly, except that a node keeps its label
+// if it is among the most common.  Rounds continue until no label changes.
+// Each change increases the number of edges with the same label at both
+// ends, so the method terminates.  Loops are ignored.
+//
+// Node order and ties are chosen with r, or with the rand package default
+// shared source if r is nil.
+//
+// Returned community has a community ID for each node of g, numbered from 0
+// in order of the first node of each community, and nc is the number of
+// communities.
+//
+// Each round is O(V + E).  Few rounds are typically needed, even for large
+// graphs.
+//
+// See also SemiSyncLabelPropagation and Louvain.
+func (g Undirected) AsyncLabelPropagation(r *rand.Rand) (community []int, nc int) {
+	return labelPropagation(len(g.AdjacencyList), g.lpNeighbors, nil, r)
+}
+
+// SemiSyncLabelPropagation finds communities of an undirected graph by
+// semi-synchronous label propagation.
+//
+// This is as described for AsyncLabelPropagation except that in each round,
+// nodes are updated by color class of a proper coloring of g, as found by
+// GreedyColoring.  Nodes of a class are not adjacent and so are updated
+// simultaneously.  This method of Cordasco and Gargano combines the
+// stability of synchronous updates with the guaranteed termination of
+// asynchronous updates.  Argument r is used only to break ties.
+//
+// Time complexity is that of AsyncLabelPropagation.
+func (g Undirected) SemiSyncLabelPropagation(r *rand.Rand) (community []int, nc int) {
+	color, _ := g.GreedyColoring(nil)
+	return labelPropagation(len(g.AdjacencyList), g.lpNeighbors, color, r)
+}
+
+// AsyncLabelPropagation finds communities of a weighted undirected graph by
+// asynchronous label propagation.
+//
+// This is as described for Undirected.AsyncLabelPropagation except that a
+// node takes the label with the greatest total weight of edges to neighbors
+// with that label.  Edges are weighted by WeightFunc w.  Weights must be
+// positive.  If w is nil, edges have weight 1.
+func (g LabeledUndirected) AsyncLabelPropagation(w WeightFunc, r *rand.Rand) (community []int, nc int) {
+	return labelPropagation(len(g.LabeledAdjacencyList), g.lpNeighbors(w),
+		nil, r)
+}
+
+// SemiSyncLabelPropagation finds communities of a weighted undirected graph
+// by semi-synchronous label propagation.
+//
+// This is as described for Undirected.SemiSyncLabelPropagation except that
+// edges are weighted as described for LabeledUndirected.AsyncLabelPropagation.
+func (g LabeledUndirected) SemiSyncLabelPropagation(w WeightFunc, r *rand.Rand) (community []int, nc int) {
+	color, _ := g.GreedyColoring(nil)
+	return labelPropagation(len(g.LabeledAdjacencyList), g.lpNeighbors(w),
+		color, r)
+}
+
+// lpNeighbors calls visit for each arc from v, with weight 1.
+func (g Undirected) lpNeighbors(v int, visit func(to int, w float64)) {
+	for _, to := range g.AdjacencyList[v] {
+		visit(int(to), 1)
+	}
+}
+
+// lpNeighbors returns a function that calls visit for each arc from v, with
+// weight given by w.
+func (g LabeledUndirected) lpNeighbors(w WeightFunc) func(int, func(int, float64)) {
+	a := g.LabeledAdjacencyList
+	if w == nil {
+		return func(v int, visit func(int, float64)) {
+			for _, h := range a[v] {
+				visit(int(h.To), 1)
+			}
+		}
+	}
+	return func(v int, visit func(int, float64)) {
+		for _, h := range a[v] {
+			visit(int(h.To), w(h.Label))
+		}
+	}
+}
+
+// labelPropagation implements label propagation for a graph of order n.
+// Nodes are updated in random order if color is nil, otherwise by color
+// class.
+func labelPropagation(n int, neighbors func(int, func(int, float64)), color []int, r *rand.Rand) (label []int, nc int) {
+	ri := rand.Intn
+	if r != nil {
+		ri = r.Intn
+	}
+	label = make([]int, n)
+	order := make([]int, n)
+	for v := range label {
+		label[v] = v
+		order[v] = v
+	}
+	if color != nil {
+		// counting sort of nodes by color
+		start := make([]int, n+1)
+		for _, c := range color {
+			start[c+1]++
+		}
+		for c := 1; c <= n; c++ {
+			start[c] += start[c-1]
+		}
+		for v, c := range color {
+			order[start[c]] = v
+			start[c]++
+		}
+	}
+	wl := make([]float64, n) // weight to neighbors, by label
+	touched := make([]bool, n)
+	var nbr, best []int // labels with touched set, labels of greatest weight
+	var v int           // node being updated
+	visit := func(to int, w float64) {
+		if to == v {
+			return
+		}
+		l := label[to]
+		if !touched[l] {
+			touched[l] = true
+			nbr = append(nbr, l)
+		}
+		wl[l] += w
+	}
+	for changed := true; changed; {
+		changed = false
+		if color == nil {
+			// Knuth-Fisher-Yates
+			for i := n; i > 1; {
+				j := ri(i)
+				i--
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+		for _, v = range order {
+			nbr = nbr[:0]
+			neighbors(v, visit)
+			if len(nbr) == 0 {
+				continue
+			}
+			max := wl[nbr[0]]
+			for _, l := range nbr[1:] {
+				if wl[l] > max {
+					max = wl[l]
+				}
+			}
+			keep := touched[label[v]] && wl[label[v]] == max
+			best = best[:0]
+			for _, l := range nbr {
+				if wl[l] == max {
+					best = append(best, l)
+				}
+				wl[l] = 0
+				touched[l] = false
+			}
+			if !keep {
+				label[v] = best[ri(len(best))]
+				changed = true
+			}
+		}
+	}
+	// renumber
+	id := make([]int, n)
+	for i := range id {
+		id[i] = -1
+	}
+	for v, l := range label {
+		if id[l] < 0 {
+			id[l] = nc
+			nc++
+		}
+		label[v] = id[l]
+	}
+	return
+}
@@ -0,0 +1,394 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleUndirected_Louvain() {
+	// Two triangles joined by an edge.
+	//  0       3
+	//  |\     /|
+	//  | 2---4 |
+	//  |/     \|
+	//  1       5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 3)
+	g.AddEdge(2, 4)
+	c, q, levels := g.Louvain()
+	fmt.Println("communities:", c)
+	fmt.Printf("modularity: %.3f\n", q)
+	fmt.Println("levels:", len(levels))
+	// Output:
+	// communities: [0 0 0 1 1 1]
+	// modularity: 0.357
+	// levels: 1
+}
+
+func ExampleLabeledUndirected_Louvain() {
+	// Labels are edge weights.  The heavy edges form the communities.
+	//     5     1     5
+	//  0-----1-----2-----3
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 5)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	g.AddEdge(graph.Edge{2, 3}, 5)
+	w := func(l graph.LI) float64 { return float64(l) }
+	c, q, _ := g.Louvain(w)
+	fmt.Println("communities:", c)
+	fmt.Printf("modularity: %.3f\n", q)
+	// Output:
+	// communities: [0 0 1 1]
+	// modularity: 0.409
+}
+
+func ExampleUndirected_Modularity() {
+	//  0       3
+	//  |\     /|
+	//  | 2---4 |
+	//  |/     \|
+	//  1       5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 3)
+	g.AddEdge(2, 4)
+	fmt.Printf("%.3f\n", g.Modularity([]int{7, 7, 7, 9, 9, 9}))
+	fmt.Printf("%.3f\n", g.Modularity([]int{0, 0, 0, 0, 0, 0}))
+	fmt.Printf("%.3f\n", g.Modularity([]int{0, 1, 2, 3, 4, 5}))
+	// Output:
+	// 0.357
+	// 0.000
+	// -0.173
+}
+
+func ExampleLabeledUndirected_Modularity() {
+	//     5     1     5
+	//  0-----1-----2-----3
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 5)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	g.AddEdge(graph.Edge{2, 3}, 5)
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Printf("%.3f\n", g.Modularity(w, []int{0, 0, 1, 1}))
+	fmt.Printf("%.3f\n", g.Modularity(nil, []int{0, 0, 1, 1}))
+	// Output:
+	// 0.409
+	// 0.167
+}
+
+// bruteModularity computes modularity from the adjacency matrix.
+func bruteModularity(g graph.LabeledUndirected, w graph.WeightFunc, c []int) float64 {
+	n := len(g.LabeledAdjacencyList)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	for fr, to := range g.LabeledAdjacencyList {
+		for _, h := range to {
+			x := w(h.Label)
+			if h.To == graph.NI(fr) {
+				x *= 2
+			}
+			a[fr][h.To] += x
+		}
+	}
+	k := make([]float64, n)
+	var m2 float64
+	for i := range a {
+		for j := range a {
+			k[i] += a[i][j]
+		}
+		m2 += k[i]
+	}
+	if m2 == 0 {
+		return 0
+	}
+	var q float64
+	for i := range a {
+		for j := range a {
+			if c[i] == c[j] {
+				q += a[i][j] - k[i]*k[j]/m2
+			}
+		}
+	}
+	return q / m2
+}
+
+// TestLouvain checks Modularity against a direct computation, checks
+// consistency of Louvain results, and checks that Louvain finds
+// communities of disjoint cliques.
+func TestLouvain(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	w := func(l graph.LI) float64 { return float64(l) }
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(20)
+		var g graph.LabeledUndirected
+		g.LabeledAdjacencyList = make(graph.LabeledAdjacencyList, n)
+		for e := r.Intn(3 * n); e > 0; e-- {
+			g.AddEdge(graph.Edge{graph.NI(r.Intn(n)), graph.NI(r.Intn(n))},
+				graph.LI(1+r.Intn(5)))
+		}
+		c, q, levels := g.Louvain(w)
+		if len(c) != n || len(levels) == 0 {
+			t.Fatal("lengths", len(c), len(levels))
+		}
+		if fmt.Sprint(levels[len(levels)-1]) != fmt.Sprint(c) {
+			t.Fatal("last level", levels[len(levels)-1], "community", c)
+		}
+		if bq := bruteModularity(g, w, c); math.Abs(q-bq) > 1e-9 {
+			t.Fatal("modularity", q, "want", bq)
+		}
+		if gq := g.Modularity(w, c); math.Abs(q-gq) > 1e-9 {
+			t.Fatal("Modularity", gq, "want", q)
+		}
+		last := math.Inf(-1)
+		for _, l := range levels {
+			// IDs are numbered in order of first node.
+			next := 0
+			for _, id := range l {
+				switch {
+				case id == next:
+					next++
+				case id > next:
+					t.Fatal("community numbering", l)
+				}
+			}
+			lq := g.Modularity(w, l)
+			if lq < last-1e-9 {
+				t.Fatal("modularity decreased", levels)
+			}
+			last = lq
+		}
+		singletons := make([]int, n)
+		for v := range singletons {
+			singletons[v] = v
+		}
+		if q < g.Modularity(w, singletons)-1e-9 {
+			t.Fatal("modularity less than singletons")
+		}
+		u := graph.Undirected{g.Unlabeled()}
+		uc, uq, _ := u.Louvain()
+		one := func(graph.LI) float64 { return 1 }
+		if bq := bruteModularity(g, one, uc); math.Abs(uq-bq) > 1e-9 {
+			t.Fatal("unlabeled modularity", uq, "want", bq)
+		}
+	}
+	// disjoint cliques, randomly numbered
+	for i := 0; i < 50; i++ {
+		nk := 1 + r.Intn(6)
+		var want []int
+		for k := 0; k < nk; k++ {
+			for s := 3 + r.Intn(4); s > 0; s-- {
+				want = append(want, k)
+			}
+		}
+		n := len(want)
+		perm := r.Perm(n)
+		pw := make([]int, n)
+		for v, p := range perm {
+			pw[p] = want[v]
+		}
+		var g graph.Undirected
+		g.AdjacencyList = make(graph.AdjacencyList, n)
+		for v := 0; v < n; v++ {
+			for u := 0; u < v; u++ {
+				if pw[u] == pw[v] {
+					g.AddEdge(graph.NI(u), graph.NI(v))
+				}
+			}
+		}
+		c, _, _ := g.Louvain()
+		for u := range c {
+			for v := range c {
+				if (c[u] == c[v]) != (pw[u] == pw[v]) {
+					t.Fatal("cliques", pw, "communities", c)
+				}
+			}
+		}
+	}
+}
+
+func ExampleUndirected_AsyncLabelPropagation() {
+	// Two 4-cliques joined by an edge.
+	var g graph.Undirected
+	for _, c := range [][]graph.NI{{0, 1, 2, 3}, {4, 5, 6, 7}} {
+		for i, u := range c {
+			for _, v := range c[i+1:] {
+				g.AddEdge(u, v)
+			}
+		}
+	}
+	g.AddEdge(3, 4)
+	c, nc := g.AsyncLabelPropagation(rand.New(rand.NewSource(1)))
+	fmt.Println(c, nc)
+	// Output:
+	// [0 0 0 0 1 1 1 1] 2
+}
+
+func ExampleUndirected_SemiSyncLabelPropagation() {
+	var g graph.Undirected
+	for _, c := range [][]graph.NI{{0, 1, 2, 3}, {4, 5, 6, 7}} {
+		for i, u := range c {
+			for _, v := range c[i+1:] {
+				g.AddEdge(u, v)
+			}
+		}
+	}
+	g.AddEdge(3, 4)
+	c, nc := g.SemiSyncLabelPropagation(rand.New(rand.NewSource(1)))
+	fmt.Println(c, nc)
+	// Output:
+	// [0 0 0 0 1 1 1 1] 2
+}
+
+func ExampleLabeledUndirected_AsyncLabelPropagation() {
+	// Labels are edge weights.  Node 2 has more weight to node 3.
+	//     5     1     4     5
+	//  0-----1-----2-----3-----4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 5)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	g.AddEdge(graph.Edge{2, 3}, 4)
+	g.AddEdge(graph.Edge{3, 4}, 5)
+	w := func(l graph.LI) float64 { return float64(l) }
+	c, nc := g.AsyncLabelPropagation(w, rand.New(rand.NewSource(1)))
+	fmt.Println(c, nc)
+	// Output:
+	// [0 0 1 1 1] 2
+}
+
+func ExampleLabeledUndirected_SemiSyncLabelPropagation() {
+	//     5     1     4     5
+	//  0-----1-----2-----3-----4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 5)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	g.AddEdge(graph.Edge{2, 3}, 4)
+	g.AddEdge(graph.Edge{3, 4}, 5)
+	w := func(l graph.LI) float64 { return float64(l) }
+	c, nc := g.SemiSyncLabelPropagation(w, rand.New(rand.NewSource(1)))
+	fmt.Println(c, nc)
+	// Output:
+	// [0 0 1 1 1] 2
+}
+
+// TestLabelPropagation checks that label propagation results are stable,
+// that is, each node has a label of greatest weight among its neighbors,
+// that results are reproducible, and that disjoint cliques are found.
+func TestLabelPropagation(t *testing.T) {
+	r := rand.New(rand.NewSource(53))
+	w := func(l graph.LI) float64 { return float64(l) }
+	type lp func(seed int64) ([]int, int)
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(30)
+		var g graph.LabeledUndirected
+		g.LabeledAdjacencyList = make(graph.LabeledAdjacencyList, n)
+		for e := r.Intn(3 * n); e > 0; e-- {
+			g.AddEdge(graph.Edge{graph.NI(r.Intn(n)), graph.NI(r.Intn(n))},
+				graph.LI(1+r.Intn(3)))
+		}
+		u := graph.Undirected{g.Unlabeled()}
+		wt := w
+		var f lp
+		switch i % 4 {
+		case 0:
+			f = func(s int64) ([]int, int) {
+				return g.AsyncLabelPropagation(w, rand.New(rand.NewSource(s)))
+			}
+		case 1:
+			f = func(s int64) ([]int, int) {
+				return g.SemiSyncLabelPropagation(w, rand.New(rand.NewSource(s)))
+			}
+		case 2:
+			wt = func(graph.LI) float64 { return 1 }
+			f = func(s int64) ([]int, int) {
+				return u.AsyncLabelPropagation(rand.New(rand.NewSource(s)))
+			}
+		case 3:
+			wt = func(graph.LI) float64 { return 1 }
+			f = func(s int64) ([]int, int) {
+				return u.SemiSyncLabelPropagation(rand.New(rand.NewSource(s)))
+			}
+		}
+		seed := r.Int63()
+		c, nc := f(seed)
+		if c2, _ := f(seed); fmt.Sprint(c2) != fmt.Sprint(c) {
+			t.Fatal("not reproducible")
+		}
+		next := 0
+		for _, id := range c {
+			switch {
+			case id == next:
+				next++
+			case id > next:
+				t.Fatal("community numbering", c)
+			}
+		}
+		if next != nc {
+			t.Fatal("nc", nc, "want", next)
+		}
+		for v, to := range g.LabeledAdjacencyList {
+			lw := map[int]float64{}
+			for _, h := range to {
+				if h.To != graph.NI(v) {
+					lw[c[h.To]] += wt(h.Label)
+				}
+			}
+			for _, x := range lw {
+				if x > lw[c[v]] {
+					t.Fatal("node", v, "label", c[v], "not stable", lw)
+				}
+			}
+		}
+	}
+	// disjoint cliques, randomly numbered
+	for i := 0; i < 50; i++ {
+		var want []int
+		for k := 1 + r.Intn(6); k > 0; k-- {
+			for s := 1 + r.Intn(5); s > 0; s-- {
+				want = append(want, k)
+			}
+		}
+		n := len(want)
+		perm := r.Perm(n)
+		pw := make([]int, n)
+		for v, p := range perm {
+			pw[p] = want[v]
+		}
+		var g graph.Undirected
+		g.AdjacencyList = make(graph.AdjacencyList, n)
+		for v := 0; v < n; v++ {
+			for u := 0; u < v; u++ {
+				if pw[u] == pw[v] {
+					g.AddEdge(graph.NI(u), graph.NI(v))
+				}
+			}
+		}
+		ca, _ := g.AsyncLabelPropagation(r)
+		cs, _ := g.SemiSyncLabelPropagation(r)
+		for u := range pw {
+			for v := range pw {
+				same := pw[u] == pw[v]
+				if (ca[u] == ca[v]) != same || (cs[u] == cs[v]) != same {
+					t.Fatal("cliques", pw, "communities", ca, cs)
+				}
+			}
+		}
+	}
+}
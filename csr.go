@@ -0,0 +1,167 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// csr.go has CSR and LabeledCSR constructors and conversions, and methods
+// that do not have code generated equivalents.
+
+import "container/heap"
+
+// CSR constructs the compressed sparse row representation of g.
+//
+// Arcs are copied in order, so the to-list of each node of the result
+// is the same as the to-list of the corresponding node of g.
+//
+// The method is promoted to Directed and Undirected.  The CSR result does not
+// distinguish directed from undirected graphs though.  Methods of CSR that
+// rely on the graph being directed or undirected are documented as such.
+//
+// See also LabeledAdjacencyList.LabeledCSR.
+func (g AdjacencyList) CSR() CSR {
+	o := make([]int, len(g)+1)
+	for n, to := range g {
+		o[n+1] = o[n] + len(to)
+	}
+	a := make([]NI, 0, o[len(g)])
+	for _, to := range g {
+		a = append(a, to...)
+	}
+	return CSR{o, a}
+}
+
+// AdjacencyList constructs the adjacency list representation of g.
+//
+// The to-lists of the result are subslices of g.Arcs and so share memory
+// with g.  Only the slice headers are allocated.  Modifying arcs of the
+// result modifies g.  Appending arcs to the result is safe however because
+// the capacity of each to-list is limited to its length.
+//
+// See also LabeledCSR.LabeledAdjacencyList.
+func (g CSR) AdjacencyList() AdjacencyList {
+	o := g.Offsets
+	a := make(AdjacencyList, g.Order())
+	for n := range a {
+		a[n] = g.Arcs[o[n]:o[n+1]:o[n+1]]
+	}
+	return a
+}
+
+// LabeledCSR constructs the compressed sparse row representation of g.
+//
+// Arcs are copied in order, so the to-list of each node of the result
+// is the same as the to-list of the corresponding node of g.
+//
+// The method is promoted to LabeledDirected and LabeledUndirected.
+// The LabeledCSR result does not distinguish directed from undirected graphs
+// though.  Methods of LabeledCSR that rely on the graph being directed or
+// undirected are documented as such.
+//
+// See also AdjacencyList.CSR.
+func (g LabeledAdjacencyList) LabeledCSR() LabeledCSR {
+	o := make([]int, len(g)+1)
+	for n, to := range g {
+		o[n+1] = o[n] + len(to)
+	}
+	a := make([]Half, 0, o[len(g)])
+	for _, to := range g {
+		a = append(a, to...)
+	}
+	return LabeledCSR{o, a}
+}
+
+// LabeledAdjacencyList constructs the adjacency list representation of g.
+//
+// The to-lists of the result are subslices of g.Arcs and so share memory
+// with g.  Only the slice headers are allocated.  Modifying arcs of the
+// result modifies g.  Appending arcs to the result is safe however because
+// the capacity of each to-list is limited to its length.
+//
+// See also CSR.AdjacencyList.
+func (g LabeledCSR) LabeledAdjacencyList() LabeledAdjacencyList {
+	o := g.Offsets
+	a := make(LabeledAdjacencyList, g.Order())
+	for n := range a {
+		a[n] = g.Arcs[o[n]:o[n+1]:o[n+1]]
+	}
+	return a
+}
+
+// Dijkstra finds shortest paths by Dijkstra's algorithm.
+//
+// The method is equivalent to LabeledAdjacencyList.Dijkstra.  See that method
+// for full documentation.
+//
+// Paths and path distances are encoded in the returned FromList and dist
+// slice.   Returned labels are the labels of arcs followed to each node.
+// The number of nodes reached is returned as nReached.
+func (g LabeledCSR) Dijkstra(start, end NI, w WeightFunc) (f FromList, labels []LI, dist []float64, nReached int) {
+	o := g.Offsets
+	r := make([]tentResult, g.Order())
+	for i := range r {
+		r[i].nx = NI(i)
+	}
+	f = NewFromList(len(r))
+	labels = make([]LI, len(r))
+	dist = make([]float64, len(r))
+	current := start
+	rp := f.Paths
+	rp[current] = PathEnd{Len: 1, From: -1} // path length at start is 1 node
+	cr := &r[current]
+	cr.dist = 0    // distance at start is 0.
+	cr.done = true // mark start done.  it skips the heap.
+	nDone := 1     // accumulated for a return value
+	var t tent
+	for current != end {
+		nextLen := rp[current].Len + 1
+		for _, nb := range g.Arcs[o[current]:o[current+1]] {
+			hr := &r[nb.To]
+			if hr.done {
+				continue // skip nodes already done
+			}
+			dist := cr.dist + w(nb.Label)
+			vl := rp[nb.To].Len
+			visited := vl > 0
+			if visited {
+				if dist > hr.dist {
+					continue // distance is worse
+				}
+				if dist == hr.dist && nextLen >= vl {
+					continue // distance same, but number of nodes is no better
+				}
+			}
+			// the path through current to this node is shortest so far.
+			// record new path data for this node and update tentative set.
+			hr.dist = dist
+			rp[nb.To].Len = nextLen
+			rp[nb.To].From = current
+			labels[nb.To] = nb.Label
+			if visited {
+				heap.Fix(&t, hr.fx)
+			} else {
+				heap.Push(&t, hr)
+			}
+		}
+		if len(t) == 0 {
+			// no more reachable nodes. AllPaths normal return
+			return f, labels, dist, nDone
+		}
+		// new current is node with smallest tentative distance
+		cr = heap.Pop(&t).(*tentResult)
+		cr.done = true
+		nDone++
+		current = cr.nx
+		dist[current] = cr.dist // store final distance
+	}
+	// normal return for single shortest path search
+	return f, labels, dist, -1
+}
+
+// DijkstraPath finds a single shortest path.
+//
+// Returned is the path as returned by FromList.LabeledPathTo and the total
+// path distance.
+func (g LabeledCSR) DijkstraPath(start, end NI, w WeightFunc) (LabeledPath, float64) {
+	f, labels, dist, _ := g.Dijkstra(start, end, w)
+	return f.PathToLabeled(end, labels, nil), dist[end]
+}
@@ -0,0 +1,229 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// csr_RO.go is code generated from csr_cg.go by directives in graph.go.
+// Editing csr_cg.go is okay.  It is the code generation source.
+// DO NOT EDIT csr_RO.go.
+// The RO means read only and it is upper case RO to slow you down a bit
+// in case you start to edit the file.
+
+import "github.com/soniakeys/bits"
+
+// ArcSize returns the number of arcs in g.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) ArcSize() int {
+	return len(g.Arcs)
+}
+
+// BreadthFirst traverses a directed or undirected graph in breadth
+// first order.
+//
+// Traversal starts at node start and visits the nodes reachable from
+// start.  The function visit is called for each node visited.  Nodes
+// not reachable from start are not visited.
+//
+// Nodes are visited in the same order as by AdjacencyList.BreadthFirst
+// on the corresponding AdjacencyList.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) BreadthFirst(start NI, visit func(NI)) {
+	o := g.Offsets
+	v := bits.New(g.Order())
+	v.SetBit(int(start), 1)
+	visit(start)
+	var next []NI
+	for frontier := []NI{start}; len(frontier) > 0; {
+		for _, n := range frontier {
+			for _, nb := range g.Arcs[o[n]:o[n+1]] {
+				if v.Bit(int(nb)) == 0 {
+					v.SetBit(int(nb), 1)
+					visit(nb)
+					next = append(next, nb)
+				}
+			}
+		}
+		frontier, next = next, frontier[:0]
+	}
+}
+
+// ConnectedComponentInts returns a list of component numbers (ints) for each
+// node of graph g.
+//
+// The receiver must represent an undirected graph.
+//
+// The method assigns numbers to components 1-based, 1 through the number of
+// components.  Return value ci contains the component number for each node.
+// Return value nc is the number of components.
+//
+// Component numbers are the same as assigned by
+// Undirected.ConnectedComponentInts on the corresponding Undirected graph.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) ConnectedComponentInts() (ci []int, nc int) {
+	o := g.Offsets
+	ci = make([]int, g.Order())
+	var df func(NI)
+	df = func(nd NI) {
+		ci[nd] = nc
+		for _, to := range g.Arcs[o[nd]:o[nd+1]] {
+			if ci[to] == 0 {
+				df(to)
+			}
+		}
+		return
+	}
+	for nd := range ci {
+		if ci[nd] == 0 {
+			nc++
+			df(NI(nd))
+		}
+	}
+	return
+}
+
+// DepthFirst traverses a directed or undirected graph in depth
+// first order.
+//
+// Traversal starts at node start and visits the nodes reachable from
+// start.  The function visit is called for each node visited.  Nodes
+// not reachable from start are not visited.
+//
+// Nodes are visited in the same order as by AdjacencyList.DepthFirst
+// on the corresponding AdjacencyList.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) DepthFirst(start NI, visit func(NI)) {
+	o := g.Offsets
+	v := bits.New(g.Order())
+	var f func(NI)
+	f = func(n NI) {
+		visit(n)
+		v.SetBit(int(n), 1)
+		for _, to := range g.Arcs[o[n]:o[n+1]] {
+			if v.Bit(int(to)) == 0 {
+				f(to)
+			}
+		}
+	}
+	f(start)
+}
+
+// Order is the number of nodes in receiver g.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) Order() int {
+	if len(g.Offsets) == 0 {
+		return 0
+	}
+	return len(g.Offsets) - 1
+}
+
+// StronglyConnectedComponents identifies strongly connected components in
+// a directed graph.
+//
+// The receiver must represent a directed graph.
+//
+// The method calls the emit function for each component identified.  The
+// argument to emit is the node list of a component.  The emit function must
+// return true for the method to continue identifying components.  If emit
+// returns false, the method returns immediately.
+//
+// Note well:  The backing slice for the node list passed to emit is reused
+// across emit calls.  If you need to retain the node list you must copy it.
+//
+// Components are emitted in the same order as by
+// Directed.StronglyConnectedComponents on the corresponding Directed graph.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) StronglyConnectedComponents(emit func([]NI) bool) {
+	// Same algorithm as Directed.StronglyConnectedComponents, by David
+	// Pearce.
+	o := g.Offsets
+	rindex := make([]int, g.Order())
+	var S, scc []NI
+	index := 1
+	c := len(rindex) - 1
+	var visit func(NI) bool
+	visit = func(v NI) bool {
+		root := true
+		rindex[v] = index
+		index++
+		for _, w := range g.Arcs[o[v]:o[v+1]] {
+			if rindex[w] == 0 {
+				if !visit(w) {
+					return false
+				}
+			}
+			if rindex[w] < rindex[v] {
+				rindex[v] = rindex[w]
+				root = false
+			}
+		}
+		if !root {
+			S = append(S, v)
+			return true
+		}
+		scc = scc[:0]
+		index--
+		for last := len(S) - 1; last >= 0; last-- {
+			w := S[last]
+			if rindex[v] > rindex[w] {
+				break
+			}
+			S = S[:last]
+			rindex[w] = c
+			scc = append(scc, w)
+			index--
+		}
+		rindex[v] = c
+		c--
+		return emit(append(scc, v))
+	}
+	for v := range rindex {
+		if rindex[v] == 0 && !visit(NI(v)) {
+			break
+		}
+	}
+}
+
+// ToList returns the to-list of node n.
+//
+// The result is a subslice of g.Arcs.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) ToList(n NI) []NI {
+	return g.Arcs[g.Offsets[n]:g.Offsets[n+1]]
+}
+
+// Transpose constructs a new graph with all arcs reversed.
+//
+// For every arc from->to of g, the result will have an arc to->from.
+//
+// Within each to-list of the result, arcs are ordered by from-node.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g CSR) Transpose() CSR {
+	o := g.Offsets
+	n := g.Order()
+	to := make([]int, n+1)
+	for _, h := range g.Arcs {
+		to[h+1]++
+	}
+	for i := 1; i <= n; i++ {
+		to[i] += to[i-1]
+	}
+	next := append([]int{}, to[:n]...)
+	ta := make([]NI, len(g.Arcs))
+	for fr := 0; fr < n; fr++ {
+		for _, h := range g.Arcs[o[fr]:o[fr+1]] {
+			x := next[h]
+			next[h]++
+			h = NI(fr)
+			ta[x] = h
+		}
+	}
+	return CSR{to, ta}
+}
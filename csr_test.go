@@ -0,0 +1,268 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleAdjacencyList_CSR() {
+	//   0
+	//  / \
+	// 1-->2
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {},
+	}
+	c := g.CSR()
+	fmt.Println("Offsets:", c.Offsets)
+	fmt.Println("Arcs:   ", c.Arcs)
+	for n := 0; n < c.Order(); n++ {
+		fmt.Println(n, c.ToList(graph.NI(n)))
+	}
+	// Output:
+	// Offsets: [0 2 3 3]
+	// Arcs:    [1 2 2]
+	// 0 [1 2]
+	// 1 [2]
+	// 2 []
+}
+
+func ExampleCSR_AdjacencyList() {
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {},
+	}
+	fmt.Println(g.CSR().AdjacencyList())
+	// Output:
+	// [[1 2] [2] []]
+}
+
+func ExampleCSR_BreadthFirst() {
+	//   0
+	//  / \
+	// 1-->2-->3
+	//      \
+	//       -->4
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3, 4},
+		4: {},
+	}
+	g.CSR().BreadthFirst(0, func(n graph.NI) {
+		fmt.Println(n)
+	})
+	// Output:
+	// 0
+	// 1
+	// 2
+	// 3
+	// 4
+}
+
+func ExampleCSR_ConnectedComponentInts() {
+	// 0--1  2  3--4--5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(2, 2)
+	fmt.Println(g.CSR().ConnectedComponentInts())
+	// Output:
+	// [1 1 2 3 3 3] 3
+}
+
+func ExampleCSR_DepthFirst() {
+	//   0
+	//  / \
+	// 1-->2-->3
+	//      \
+	//       -->4
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {3, 4},
+		4: {},
+	}
+	g.CSR().DepthFirst(0, func(n graph.NI) {
+		fmt.Println(n)
+	})
+	// Output:
+	// 0
+	// 1
+	// 2
+	// 3
+	// 4
+}
+
+func ExampleCSR_StronglyConnectedComponents() {
+	// /---0---\
+	// |   |\--/
+	// |   v
+	// |   5<=>4---\
+	// |   |   |   |
+	// v   v   |   |
+	// 7<=>6   |   |
+	//     |   v   v
+	//     \-->3<--2
+	//         |   ^
+	//         |   |
+	//         \-->1
+	g := graph.Directed{graph.AdjacencyList{
+		0: {0, 5, 7},
+		5: {4, 6},
+		4: {5, 2, 3},
+		7: {6},
+		6: {7, 3},
+		3: {1},
+		1: {2},
+		2: {3},
+	}}
+	g.CSR().StronglyConnectedComponents(func(c []graph.NI) bool {
+		fmt.Println(c)
+		return true
+	})
+	// Output:
+	// [3 1 2]
+	// [7 6]
+	// [4 5]
+	// [0]
+}
+
+func ExampleCSR_Transpose() {
+	//   0
+	//  / \
+	// 1-->2
+	g := graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {},
+	}
+	fmt.Println(g.CSR().Transpose().AdjacencyList())
+	// Output:
+	// [[] [0] [0 1]]
+}
+
+func ExampleLabeledCSR_Dijkstra() {
+	// arcs are shown with label
+	//      (3)
+	//   0---->1
+	//   |     |
+	// (1)   (1)
+	//   v     v
+	//   2---->3
+	//      (5)
+	g := graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 3}, {To: 2, Label: 1}},
+		1: {{To: 3, Label: 1}},
+		2: {{To: 3, Label: 5}},
+		3: {},
+	}
+	w := func(l graph.LI) float64 { return float64(l) }
+	f, _, dist, _ := g.LabeledCSR().Dijkstra(0, -1, w)
+	for n := range dist {
+		fmt.Println(n, f.PathTo(graph.NI(n), nil), dist[n])
+	}
+	// Output:
+	// 0 [0] 0
+	// 1 [0 1] 3
+	// 2 [0 2] 1
+	// 3 [0 1 3] 4
+}
+
+func ExampleLabeledCSR_DijkstraPath() {
+	g := graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 3}, {To: 2, Label: 1}},
+		1: {{To: 3, Label: 1}},
+		2: {{To: 3, Label: 5}},
+		3: {},
+	}
+	w := func(l graph.LI) float64 { return float64(l) }
+	fmt.Println(g.LabeledCSR().DijkstraPath(0, 3, w))
+	// Output:
+	// {0 [{1 3} {3 1}]} 4
+}
+
+func ExampleLabeledCSR_Transpose() {
+	g := graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 3}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 4}},
+		2: {},
+	}
+	for n, to := range g.LabeledCSR().Transpose().LabeledAdjacencyList() {
+		fmt.Println(n, to)
+	}
+	// Output:
+	// 0 []
+	// 1 [{0 3}]
+	// 2 [{0 1} {1 4}]
+}
+
+// TestCSR compares CSR methods to corresponding AdjacencyList methods on
+// random graphs.
+func TestCSR(t *testing.T) {
+	r := rand.New(rand.NewSource(12))
+	for i := 0; i < 20; i++ {
+		d := graph.GnmDirected(50, 100, r)
+		c := d.CSR()
+		if !c.AdjacencyList().Equal(d.AdjacencyList) {
+			t.Fatal("round trip")
+		}
+		var want, got []graph.NI
+		d.BreadthFirst(0, func(n graph.NI) { want = append(want, n) })
+		c.BreadthFirst(0, func(n graph.NI) { got = append(got, n) })
+		if !reflect.DeepEqual(want, got) {
+			t.Fatal("BreadthFirst", want, got)
+		}
+		want, got = want[:0], got[:0]
+		d.DepthFirst(0, func(n graph.NI) { want = append(want, n) })
+		c.DepthFirst(0, func(n graph.NI) { got = append(got, n) })
+		if !reflect.DeepEqual(want, got) {
+			t.Fatal("DepthFirst", want, got)
+		}
+		tr, _ := d.Transpose()
+		if !c.Transpose().AdjacencyList().Equal(tr.AdjacencyList) {
+			t.Fatal("Transpose")
+		}
+		want, got = want[:0], got[:0]
+		d.StronglyConnectedComponents(func(s []graph.NI) bool {
+			want = append(append(want, s...), -1)
+			return true
+		})
+		c.StronglyConnectedComponents(func(s []graph.NI) bool {
+			got = append(append(got, s...), -1)
+			return true
+		})
+		if !reflect.DeepEqual(want, got) {
+			t.Fatal("StronglyConnectedComponents", want, got)
+		}
+		u := graph.GnmUndirected(50, 40, r)
+		wci, wnc := u.ConnectedComponentInts()
+		gci, gnc := u.CSR().ConnectedComponentInts()
+		if gnc != wnc || !reflect.DeepEqual(wci, gci) {
+			t.Fatal("ConnectedComponentInts")
+		}
+	}
+}
+
+func TestLabeledCSRDijkstra(t *testing.T) {
+	tc := r(100, 200, 62)
+	w := func(l graph.LI) float64 { return tc.w[l] }
+	wf, wl, wd, wn := tc.l.Dijkstra(tc.start, -1, w)
+	gf, gl, gd, gn := tc.l.LabeledCSR().Dijkstra(tc.start, -1, w)
+	if gn != wn ||
+		!reflect.DeepEqual(wf.Paths, gf.Paths) ||
+		!reflect.DeepEqual(wl, gl) ||
+		!reflect.DeepEqual(wd, gd) {
+		t.Fatal("Dijkstra mismatch")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// cut.go has minimum cut algorithms for undirected graphs.
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/soniakeys/bits"
+)
+
+// StoerWagner finds a global minimum cut of an undirected graph.
+//
+// Each edge has unit weight, so parallel edges add.  Loops are ignored.
+//
+// Returned is the number of edges of a minimum cut and one side of the cut.
+// For a graph of fewer than two nodes, cut is 0 and part is empty.
+// For a disconnected graph cut is 0 and part is a union of components.
+//
+// See LabeledUndirected.StoerWagner for a weighted version.
+func (g Undirected) StoerWagner() (cut int, part bits.Bits) {
+	a := g.AdjacencyList
+	c, part := stoerWagner(len(a), func(n NI, nb func(NI, float64)) {
+		for _, to := range a[n] {
+			nb(to, 1)
+		}
+	})
+	return int(c), part
+}
+
+// StoerWagner finds a global minimum cut of an undirected graph by the
+// algorithm of Stoer and Wagner.
+//
+// Edge weights are given by WeightFunc w and must be non-negative.  Parallel
+// edges add.  Loops are ignored.
+//
+// Returned is the total weight of a minimum cut and one side of the cut.
+// For a graph of fewer than two nodes, cut is 0 and part is empty.
+// For a disconnected graph cut is 0 and part is a union of components.
+//
+// Time complexity is O(VE log V).
+func (g LabeledUndirected) StoerWagner(w WeightFunc) (cut float64, part bits.Bits) {
+	a := g.LabeledAdjacencyList
+	return stoerWagner(len(a), func(n NI, nb func(NI, float64)) {
+		for _, to := range a[n] {
+			nb(to.To, w(to.Label))
+		}
+	})
+}
+
+// stoerWagner implements StoerWagner for a graph of order n.  Function
+// arcs must call nb with the to-node and weight of each arc from a node.
+func stoerWagner(n int, arcs func(NI, func(NI, float64))) (cut float64, part bits.Bits) {
+	part = bits.New(n)
+	if n < 2 {
+		return 0, part
+	}
+	// Merged nodes are represented by disjoint set roots.  members lists
+	// the original nodes of each root.
+	ds := newDisjointSet(n)
+	members := make([][]NI, n)
+	roots := make([]NI, n)
+	for i := range roots {
+		roots[i] = NI(i)
+		members[i] = []NI{NI(i)}
+	}
+	r := make([]tentResult, n)
+	var best []NI
+	cut = math.Inf(1)
+	for len(roots) > 1 {
+		// A phase orders nodes by maximum adjacency to nodes already
+		// ordered.  Adjacency is accumulated as a negative heap key.
+		var h tent
+		for _, v := range roots {
+			r[v] = tentResult{nx: v}
+			heap.Push(&h, &r[v])
+		}
+		s, t := NI(-1), NI(-1)
+		var tAdj float64
+		for len(h) > 0 {
+			c := heap.Pop(&h).(*tentResult)
+			c.done = true
+			s, t = t, c.nx
+			tAdj = -c.dist
+			for _, m := range members[t] {
+				arcs(m, func(to NI, wt float64) {
+					if rv := &r[ds.find(to)]; !rv.done {
+						rv.dist -= wt
+						heap.Fix(&h, rv.fx)
+					}
+				})
+			}
+		}
+		// the cut of the phase separates t from the rest
+		if tAdj < cut {
+			cut = tAdj
+			best = members[t]
+		}
+		// merge s and t
+		m := append(append([]NI{}, members[s]...), members[t]...)
+		members[s], members[t] = nil, nil
+		ds.union(s, t)
+		root := ds.find(s)
+		members[root] = m
+		dead := s + t - root
+		for i, v := range roots {
+			if v == dead {
+				last := len(roots) - 1
+				roots[i] = roots[last]
+				roots = roots[:last]
+				break
+			}
+		}
+	}
+	for _, m := range best {
+		part.SetBit(int(m), 1)
+	}
+	return
+}
+
+// GomoryHu constructs a Gomory-Hu tree of an undirected graph.
+//
+// Edge capacities are given by WeightFunc capacity and must be non-negative.
+// Parallel edges add.  Loops are ignored.
+//
+// The tree is returned as a FromList rooted at node 0, with Leaves and MaxLen
+// set, and a cut value for each node.  For each node n other than the root,
+// cut[n] is the capacity of a minimum cut between n and its parent in the
+// tree, f.Paths[n].From.  For the root, cut[0] is +Inf.  For any two nodes
+// s and t of g, the capacity of a minimum s-t cut is the minimum of cut
+// values along the tree path between s and t.  That is, the minimum of cut
+// values of nodes on the path, excluding the lowest common ancestor of
+// s and t.
+//
+// The tree is computed by Gusfield's algorithm with g.Order()-1 maximum
+// flow computations by Dinic's algorithm.
+func (g LabeledUndirected) GomoryHu(capacity WeightFunc) (f FromList, cut []float64) {
+	a := g.LabeledAdjacencyList
+	f = NewFromList(len(a))
+	cut = make([]float64, len(a))
+	if len(a) == 0 {
+		return
+	}
+	p := f.Paths
+	p[0].From = -1
+	cut[0] = math.Inf(1)
+	// each edge is represented by two arcs, one in each direction,
+	// so the directed flow network is equivalent to the undirected graph.
+	fn := newFlowNet(a, capacity, nil)
+	for s := NI(1); int(s) < len(a); s++ {
+		t := p[s].From
+		fn.reset()
+		value := fn.dinic(s, t)
+		side := fn.reachable(s)
+		cut[s] = value
+		for i := range p {
+			if NI(i) != s && p[i].From == t && side.Bit(i) == 1 {
+				p[i].From = s
+			}
+		}
+		if pt := p[t].From; pt >= 0 && side.Bit(int(pt)) == 1 {
+			p[s].From = pt
+			p[t].From = s
+			cut[s] = cut[t]
+			cut[t] = value
+		}
+	}
+	f.RecalcLeaves()
+	f.RecalcLen()
+	return
+}
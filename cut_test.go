@@ -0,0 +1,165 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleUndirected_StoerWagner() {
+	// Two complete graphs on four nodes, joined by two edges.
+	var g graph.Undirected
+	for _, k := range [][]graph.NI{{0, 1, 2, 3}, {4, 5, 6, 7}} {
+		for i, n1 := range k {
+			for _, n2 := range k[i+1:] {
+				g.AddEdge(n1, n2)
+			}
+		}
+	}
+	g.AddEdge(0, 4)
+	g.AddEdge(3, 7)
+	cut, part := g.StoerWagner()
+	fmt.Println(cut, part.Slice())
+	// Output:
+	// 2 [4 5 6 7]
+}
+
+func ExampleLabeledUndirected_StoerWagner() {
+	// The example graph of Stoer and Wagner, with nodes numbered from 0.
+	// Labels are edge weights.
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 2)
+	g.AddEdge(graph.Edge{0, 4}, 3)
+	g.AddEdge(graph.Edge{1, 2}, 3)
+	g.AddEdge(graph.Edge{1, 4}, 2)
+	g.AddEdge(graph.Edge{1, 5}, 2)
+	g.AddEdge(graph.Edge{2, 3}, 4)
+	g.AddEdge(graph.Edge{2, 6}, 2)
+	g.AddEdge(graph.Edge{3, 6}, 2)
+	g.AddEdge(graph.Edge{3, 7}, 2)
+	g.AddEdge(graph.Edge{4, 5}, 3)
+	g.AddEdge(graph.Edge{5, 6}, 1)
+	g.AddEdge(graph.Edge{6, 7}, 3)
+	cut, part := g.StoerWagner(func(l graph.LI) float64 { return float64(l) })
+	fmt.Println(cut, part.Slice())
+	// Output:
+	// 4 [2 3 6 7]
+}
+
+// TestStoerWagner compares StoerWagner to the minimum over all cuts of
+// small random graphs.
+func TestStoerWagner(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		n := 2 + r.Intn(9)
+		g := graph.LabeledUndirected{make(graph.LabeledAdjacencyList, n)}
+		for e := r.Intn(n * 3); e > 0; e-- {
+			g.AddEdge(graph.Edge{graph.NI(r.Intn(n)), graph.NI(r.Intn(n))},
+				graph.LI(r.Intn(10)))
+		}
+		w := func(l graph.LI) float64 { return float64(l) }
+		cutWeight := func(side func(graph.NI) bool) (c float64) {
+			g.Edges(func(e graph.LabeledEdge) {
+				if side(e.N1) != side(e.N2) {
+					c += w(e.LI)
+				}
+			})
+			return
+		}
+		want := -1.
+		for s := 1; s < 1<<uint(n)-1; s++ {
+			c := cutWeight(func(n graph.NI) bool { return s>>uint(n)&1 == 1 })
+			if want < 0 || c < want {
+				want = c
+			}
+		}
+		got, part := g.StoerWagner(w)
+		if got != want {
+			t.Fatal("cut", got, "want", want)
+		}
+		if pc := part.OnesCount(); pc == 0 || pc == n {
+			t.Fatal("part not a proper subset", part.Slice())
+		}
+		if c := cutWeight(func(n graph.NI) bool {
+			return part.Bit(int(n)) == 1
+		}); c != got {
+			t.Fatal("part cut", c, "want", got)
+		}
+	}
+}
+
+func ExampleLabeledUndirected_GomoryHu() {
+	// The example graph of Stoer and Wagner, with nodes numbered from 0.
+	// Labels are edge capacities.
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 2)
+	g.AddEdge(graph.Edge{0, 4}, 3)
+	g.AddEdge(graph.Edge{1, 2}, 3)
+	g.AddEdge(graph.Edge{1, 4}, 2)
+	g.AddEdge(graph.Edge{1, 5}, 2)
+	g.AddEdge(graph.Edge{2, 3}, 4)
+	g.AddEdge(graph.Edge{2, 6}, 2)
+	g.AddEdge(graph.Edge{3, 6}, 2)
+	g.AddEdge(graph.Edge{3, 7}, 2)
+	g.AddEdge(graph.Edge{4, 5}, 3)
+	g.AddEdge(graph.Edge{5, 6}, 1)
+	g.AddEdge(graph.Edge{6, 7}, 3)
+	f, cut := g.GomoryHu(func(l graph.LI) float64 { return float64(l) })
+	for n, e := range f.Paths {
+		fmt.Println(n, e.From, cut[n])
+	}
+	// Output:
+	// 0 -1 +Inf
+	// 1 4 7
+	// 2 1 4
+	// 3 2 7
+	// 4 0 5
+	// 5 1 6
+	// 6 3 7
+	// 7 6 5
+}
+
+// TestGomoryHu compares min cuts from Gomory-Hu trees to min cuts computed
+// directly with max flow.
+func TestGomoryHu(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for i := 0; i < 30; i++ {
+		n := 2 + r.Intn(15)
+		g := graph.LabeledUndirected{make(graph.LabeledAdjacencyList, n)}
+		for e := r.Intn(n * 3); e > 0; e-- {
+			g.AddEdge(graph.Edge{graph.NI(r.Intn(n)), graph.NI(r.Intn(n))},
+				graph.LI(r.Intn(10)))
+		}
+		w := func(l graph.LI) float64 { return float64(l) }
+		f, cut := g.GomoryHu(w)
+		if len(f.Paths) != n || f.Paths[0].From != -1 {
+			t.Fatal("not rooted at 0")
+		}
+		// directed version for max flow
+		d := graph.LabeledDirected{g.LabeledAdjacencyList}
+		for s := 0; s < n; s++ {
+			for tn := s + 1; tn < n; tn++ {
+				want, _, _ := d.Dinic(graph.NI(s), graph.NI(tn), w)
+				// path minimum, walking up from the deeper node
+				got := math.Inf(1)
+				u, v := graph.NI(s), graph.NI(tn)
+				for u != v {
+					if f.Paths[u].Len < f.Paths[v].Len {
+						u, v = v, u
+					}
+					got = math.Min(got, cut[u])
+					u = f.Paths[u].From
+				}
+				if got != want {
+					t.Fatal(s, tn, "got", got, "want", want)
+				}
+			}
+		}
+	}
+}
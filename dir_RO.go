@@ -753,6 +753,9 @@ func (g Directed) IsTree(root NI) (isTree, allTree bool) {
 //
 // Returned is the PageRank score for each node of g.
 //
+// See also PersonalizedPageRank, which iterates to a convergence tolerance
+// and handles nodes without out arcs.
+//
 // There are equivalent labeled and unlabeled versions of this method.
 func (g Directed) PageRank(d float64, n int) []float64 {
 	// Following "PageRank Explained" by Ian Rogers, accessed at
@@ -1089,3 +1092,50 @@ func (g Directed) TransitiveClosure() []bits.Bits {
 	}
 	return t
 }
+
+// Triangles counts cycle and flow triangles of a directed graph.
+//
+// A cycle triangle is three arcs u->v, v->w, w->u on distinct nodes u, v, w.
+// A flow, or transitive, triangle is three arcs u->v, v->w, u->w.  Where
+// reciprocal arcs exist, three nodes can form multiple triangles of either
+// kind and each is counted.  For example, three nodes with all six possible
+// arcs form two cycle triangles and six flow triangles.  Loops and parallel
+// arcs are ignored.
+//
+// Time complexity is O(E^1.5).
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Directed) Triangles() (cycle, flow int) {
+	a := g.AdjacencyList
+	tr, _ := g.Transpose()
+	ta := tr.AdjacencyList
+	t := newTriGraph(len(a), func(n NI, visit func(NI, uint8)) {
+		for _, to := range a[n] {
+			visit(to, 1)
+		}
+		for _, to := range ta[n] {
+			visit(to, 2)
+		}
+	})
+	t.each(func(u, v, w NI, duv, duw, dvw uint8) {
+		// arc[i][j] for nodes 0, 1, 2 = u, v, w
+		var arc [3][3]bool
+		arc[0][1], arc[1][0] = duv&1 != 0, duv&2 != 0
+		arc[0][2], arc[2][0] = duw&1 != 0, duw&2 != 0
+		arc[1][2], arc[2][1] = dvw&1 != 0, dvw&2 != 0
+		if arc[0][1] && arc[1][2] && arc[2][0] {
+			cycle++
+		}
+		if arc[0][2] && arc[2][1] && arc[1][0] {
+			cycle++
+		}
+		for _, p := range [6][3]int{
+			{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0},
+		} {
+			if arc[p[0]][p[1]] && arc[p[1]][p[2]] && arc[p[0]][p[2]] {
+				flow++
+			}
+		}
+	})
+	return
+}
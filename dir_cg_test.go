@@ -714,6 +714,27 @@ func ExampleLabeledDirected_TransitiveClosure() {
 	// 8: 0 0 0 0 0 1 1 1 1
 }
 
+func ExampleLabeledDirected_Triangles() {
+	//   0<=>2        3-->5
+	//    \  ^         \  ^
+	//     v |          v |
+	//      1            4
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1}, {To: 2}},
+		1: {{To: 2}},
+		2: {{To: 0}},
+		3: {{To: 4}, {To: 5}},
+		4: {{To: 5}},
+		5: {},
+	}}
+	cycle, flow := g.Triangles()
+	fmt.Println("cycle:", cycle)
+	fmt.Println("flow: ", flow)
+	// Output:
+	// cycle: 1
+	// flow:  2
+}
+
 func ExampleLabeledDirectedSubgraph_AddNode() {
 	// supergraph:
 	//    0
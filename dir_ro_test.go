@@ -733,6 +733,27 @@ func ExampleDirected_TransitiveClosure() {
 	// 8: 0 0 0 0 0 1 1 1 1
 }
 
+func ExampleDirected_Triangles() {
+	//   0<=>2        3-->5
+	//    \  ^         \  ^
+	//     v |          v |
+	//      1            4
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1, 2},
+		1: {2},
+		2: {0},
+		3: {4, 5},
+		4: {5},
+		5: {},
+	}}
+	cycle, flow := g.Triangles()
+	fmt.Println("cycle:", cycle)
+	fmt.Println("flow: ", flow)
+	// Output:
+	// cycle: 1
+	// flow:  2
+}
+
 func ExampleDirectedSubgraph_AddNode() {
 	// supergraph:
 	//    0
@@ -0,0 +1,508 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// flow.go has network flow algorithms.
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"github.com/soniakeys/bits"
+)
+
+// flowNet is a residual network constructed from a labeled graph.
+//
+// Arcs are stored in compressed sparse row form.  For each node n the
+// residual arcs a[o[n]:o[n+1]] are first the forward arcs in the order of
+// the to-list of the graph, then reverse arcs of arcs leading to n.  Thus the
+// forward residual arc of graph arc g[n][x] is a[o[n]+x].
+type flowNet struct {
+	o []int     // offsets into a
+	a []flowArc // residual arcs
+}
+
+type flowArc struct {
+	to   NI
+	rev  int     // index in a of the paired arc
+	cap  float64 // residual capacity
+	cap0 float64 // original capacity, 0 for reverse arcs
+	cost float64 // cost per unit flow, negated for reverse arcs
+}
+
+// newFlowNet constructs a residual network for g with capacities from
+// WeightFunc capacity.  Negative and NaN capacities are taken as zero.
+// WeightFunc cost may be nil if costs are not needed.
+func newFlowNet(g LabeledAdjacencyList, capacity, cost WeightFunc) *flowNet {
+	o := make([]int, len(g)+1)
+	for fr, to := range g {
+		o[fr+1] += len(to)
+		for _, to := range to {
+			o[to.To+1]++
+		}
+	}
+	for n := 1; n < len(o); n++ {
+		o[n] += o[n-1]
+	}
+	// next is the next free index for reverse arcs of each node
+	next := make([]int, len(g))
+	for n, to := range g {
+		next[n] = o[n] + len(to)
+	}
+	a := make([]flowArc, o[len(g)])
+	for fr, to := range g {
+		for x, to := range to {
+			c := capacity(to.Label)
+			if !(c > 0) {
+				c = 0
+			}
+			var k float64
+			if cost != nil {
+				k = cost(to.Label)
+			}
+			fx := o[fr] + x
+			rx := next[to.To]
+			next[to.To]++
+			a[fx] = flowArc{to: to.To, rev: rx, cap: c, cap0: c, cost: k}
+			a[rx] = flowArc{to: NI(fr), rev: fx, cost: -k}
+		}
+	}
+	return &flowNet{o, a}
+}
+
+// reset restores original capacities, removing all flow.
+func (fn *flowNet) reset() {
+	for i := range fn.a {
+		fn.a[i].cap = fn.a[i].cap0
+	}
+}
+
+// push moves flow f along residual arc x.
+func (fn *flowNet) push(x int, f float64) {
+	a := &fn.a[x]
+	a.cap -= f
+	fn.a[a.rev].cap += f
+}
+
+// arcFlow returns flow on the arcs of g, indexed parallel to g.
+func (fn *flowNet) arcFlow(g LabeledAdjacencyList) [][]float64 {
+	f := make([][]float64, len(g))
+	for n, to := range g {
+		fl := make([]float64, len(to))
+		for x := range to {
+			// flow is the residual capacity of the reverse arc.  This
+			// works for arcs of infinite capacity as well.
+			fl[x] = fn.a[fn.a[fn.o[n]+x].rev].cap
+		}
+		f[n] = fl
+	}
+	return f
+}
+
+// flowCost returns the total cost of flow on the arcs of g.
+func flowCost(g LabeledAdjacencyList, arcFlow [][]float64, cost WeightFunc) (c float64) {
+	for n, to := range g {
+		for x, to := range to {
+			if f := arcFlow[n][x]; f != 0 {
+				c += f * cost(to.Label)
+			}
+		}
+	}
+	return
+}
+
+// reachable returns nodes reachable from s in the residual network.
+func (fn *flowNet) reachable(s NI) bits.Bits {
+	r := bits.New(len(fn.o) - 1)
+	r.SetBit(int(s), 1)
+	q := []NI{s}
+	for len(q) > 0 {
+		n := q[len(q)-1]
+		q = q[:len(q)-1]
+		for _, a := range fn.a[fn.o[n]:fn.o[n+1]] {
+			if a.cap > 0 && r.Bit(int(a.to)) == 0 {
+				r.SetBit(int(a.to), 1)
+				q = append(q, a.to)
+			}
+		}
+	}
+	return r
+}
+
+// dinic computes a maximum flow from s to t by Dinic's algorithm.
+// It returns the flow value.
+func (fn *flowNet) dinic(s, t NI) (value float64) {
+	if s == t {
+		return 0
+	}
+	nn := len(fn.o) - 1
+	level := make([]int, nn)
+	it := make([]int, nn) // current arc of each node
+	q := make([]NI, 0, nn)
+	bfs := func() bool {
+		for n := range level {
+			level[n] = -1
+		}
+		level[s] = 0
+		q = append(q[:0], s)
+		for i := 0; i < len(q); i++ {
+			n := q[i]
+			for _, a := range fn.a[fn.o[n]:fn.o[n+1]] {
+				if a.cap > 0 && level[a.to] < 0 {
+					level[a.to] = level[n] + 1
+					q = append(q, a.to)
+				}
+			}
+		}
+		return level[t] >= 0
+	}
+	var dfs func(NI, float64) float64
+	dfs = func(n NI, f float64) float64 {
+		if n == t {
+			return f
+		}
+		for ; it[n] < fn.o[n+1]; it[n]++ {
+			x := it[n]
+			a := &fn.a[x]
+			if a.cap > 0 && level[a.to] == level[n]+1 {
+				if d := dfs(a.to, math.Min(f, a.cap)); d > 0 {
+					fn.push(x, d)
+					return d
+				}
+			}
+		}
+		return 0
+	}
+	for bfs() {
+		copy(it, fn.o)
+		for {
+			f := dfs(s, math.Inf(1))
+			if f == 0 {
+				break
+			}
+			value += f
+		}
+	}
+	return
+}
+
+// pushRelabel computes a maximum flow from s to t by the push-relabel
+// algorithm.  It returns the flow value.
+func (fn *flowNet) pushRelabel(s, t NI) (value float64) {
+	if s == t {
+		return 0
+	}
+	nn := len(fn.o) - 1
+	height := make([]int, nn)
+	excess := make([]float64, nn)
+	it := make([]int, nn)        // current arc of each node
+	count := make([]int, 2*nn+1) // number of nodes at each height
+	active := bits.New(nn)
+	var q []NI // FIFO queue of active nodes
+	activate := func(n NI) {
+		if n != s && n != t && active.Bit(int(n)) == 0 {
+			active.SetBit(int(n), 1)
+			q = append(q, n)
+		}
+	}
+	// An initial push of infinite capacity arcs would create infinite
+	// excess.  Bound it by the total of finite capacities, which bounds
+	// the flow through any arc when there is no path of infinite capacity.
+	bound := 0.
+	for _, a := range fn.a {
+		if !math.IsInf(a.cap0, 1) {
+			bound += a.cap0
+		}
+	}
+	copy(it, fn.o)
+	height[s] = nn
+	count[0] = nn - 1
+	count[nn] = 1
+	for x := fn.o[s]; x < fn.o[s+1]; x++ {
+		if f := math.Min(fn.a[x].cap, bound); f > 0 {
+			to := fn.a[x].to
+			fn.push(x, f)
+			excess[to] += f
+			excess[s] -= f
+			activate(to)
+		}
+	}
+	for len(q) > 0 {
+		n := q[0]
+		q = q[1:]
+		active.SetBit(int(n), 0)
+		// discharge n
+		for excess[n] > 0 {
+			if it[n] == fn.o[n+1] {
+				// relabel
+				old := height[n]
+				h := 2 * nn
+				for _, a := range fn.a[fn.o[n]:fn.o[n+1]] {
+					if a.cap > 0 && height[a.to]+1 < h {
+						h = height[a.to] + 1
+					}
+				}
+				count[old]--
+				height[n] = h
+				count[h]++
+				it[n] = fn.o[n]
+				if count[old] == 0 && old < nn {
+					// gap heuristic: nodes above the gap cannot reach t
+					for m, hm := range height {
+						if hm > old && hm < nn {
+							count[hm]--
+							height[m] = nn + 1
+							count[nn+1]++
+						}
+					}
+				}
+				continue
+			}
+			x := it[n]
+			a := &fn.a[x]
+			if a.cap > 0 && height[n] == height[a.to]+1 {
+				f := math.Min(excess[n], a.cap)
+				fn.push(x, f)
+				excess[n] -= f
+				excess[a.to] += f
+				activate(a.to)
+				if a.cap > 0 {
+					continue // excess exhausted, keep current arc
+				}
+			}
+			it[n]++
+		}
+	}
+	return excess[t]
+}
+
+// ssp sends flow along successive shortest paths by cost, from nodes with
+// positive excess to nodes with negative excess, until no node with negative
+// excess is reachable from a node with positive excess.
+//
+// Potentials pi must make reduced costs of residual arcs non-negative.
+// Excess and pi are updated.  The amount of flow sent is returned.  If a
+// path of unbounded capacity is found, +Inf is returned immediately.
+func (fn *flowNet) ssp(excess, pi []float64) (sent float64) {
+	nn := len(fn.o) - 1
+	inf := math.Inf(1)
+	r := make([]tentResult, nn)
+	pred := make([]int, nn) // index of residual arc leading to each node
+	var h tent
+	for {
+		for i := range r {
+			r[i] = tentResult{dist: inf, nx: NI(i)}
+			pred[i] = -1
+		}
+		for n, e := range excess {
+			if e > 0 {
+				r[n].dist = 0
+				heap.Push(&h, &r[n])
+			}
+		}
+		// Dijkstra, stopping at the first node with negative excess
+		sink := NI(-1)
+		var last float64
+		for len(h) > 0 {
+			c := heap.Pop(&h).(*tentResult)
+			c.done = true
+			u := c.nx
+			last = c.dist
+			if excess[u] < 0 {
+				sink = u
+				break
+			}
+			for x := fn.o[u]; x < fn.o[u+1]; x++ {
+				a := &fn.a[x]
+				if !(a.cap > 0) || r[a.to].done {
+					continue
+				}
+				d := c.dist + a.cost + pi[u] - pi[a.to]
+				if d < c.dist {
+					d = c.dist // reduced costs are non-negative but for rounding
+				}
+				if d < r[a.to].dist {
+					queued := r[a.to].dist < inf
+					r[a.to].dist = d
+					pred[a.to] = x
+					if queued {
+						heap.Fix(&h, r[a.to].fx)
+					} else {
+						heap.Push(&h, &r[a.to])
+					}
+				}
+			}
+		}
+		h = h[:0]
+		if sink < 0 {
+			return
+		}
+		// nodes not done are at least as far as the sink
+		for i := range r {
+			if r[i].done {
+				pi[i] += r[i].dist
+			} else {
+				pi[i] += last
+			}
+		}
+		f := -excess[sink]
+		src := sink
+		for pred[src] >= 0 {
+			a := &fn.a[pred[src]]
+			f = math.Min(f, a.cap)
+			src = fn.a[a.rev].to
+		}
+		f = math.Min(f, excess[src])
+		if math.IsInf(f, 1) {
+			return inf
+		}
+		for n := sink; pred[n] >= 0; n = fn.a[fn.a[pred[n]].rev].to {
+			fn.push(pred[n], f)
+		}
+		excess[src] -= f
+		excess[sink] += f
+		sent += f
+	}
+}
+
+// Dinic computes a maximum flow from node s to node t by Dinic's algorithm.
+//
+// Arc capacities are given by WeightFunc capacity.  Capacities must be
+// non-negative.  Capacities may be +Inf as long as there is no path from s
+// to t of only infinite capacity arcs.  Loops and parallel arcs are allowed.
+// Arcs in the opposite direction of any arc are not needed.  Residual arcs
+// are handled internally.
+//
+// Returned is the maximum flow value, the flow on each arc of g, indexed
+// parallel to g, and the source side of a minimum s-t cut.  The capacities
+// of arcs leading from nodes of the cut to nodes not in the cut sum to the
+// flow value.
+//
+// Time complexity is O(V²E).
+//
+// See also PushRelabel.
+func (g LabeledDirected) Dinic(s, t NI, capacity WeightFunc) (value float64, arcFlow [][]float64, cut bits.Bits) {
+	fn := newFlowNet(g.LabeledAdjacencyList, capacity, nil)
+	value = fn.dinic(s, t)
+	return value, fn.arcFlow(g.LabeledAdjacencyList), fn.reachable(s)
+}
+
+// PushRelabel computes a maximum flow from node s to node t by the
+// push-relabel algorithm of Goldberg and Tarjan.
+//
+// Arguments and results are the same as for Dinic.  See Dinic.
+//
+// This implementation uses FIFO selection of active nodes and the gap
+// heuristic.  Time complexity is O(V³).  It can be faster than Dinic on
+// dense graphs.
+func (g LabeledDirected) PushRelabel(s, t NI, capacity WeightFunc) (value float64, arcFlow [][]float64, cut bits.Bits) {
+	fn := newFlowNet(g.LabeledAdjacencyList, capacity, nil)
+	value = fn.pushRelabel(s, t)
+	return value, fn.arcFlow(g.LabeledAdjacencyList), fn.reachable(s)
+}
+
+// MinCostFlow computes a minimum cost flow from node s to node t.
+//
+// Arc capacities are given by WeightFunc capacity and costs per unit of flow
+// by WeightFunc cost.  Capacities must be non-negative and may be +Inf.
+// Costs may be negative but there must be no cycle of negative cost and
+// positive capacity reachable from s.  Residual arcs are handled internally.
+//
+// Flow of up to value limit is sent.  Limit may be +Inf to compute a minimum
+// cost maximum flow.
+//
+// Returned is the flow value, which is less than limit if the maximum flow
+// is less than limit, the total cost, and the flow on each arc of g, indexed
+// parallel to g.  A non-nil error is returned if a negative cost cycle is
+// found or if limit is +Inf and there is a path from s to t of only infinite
+// capacity arcs.
+//
+// The algorithm is successive shortest paths, with initial node potentials
+// from BellmanFord and shortest paths by Dijkstra's algorithm on reduced
+// costs.
+//
+// See also MinCostCirculation.
+func (g LabeledDirected) MinCostFlow(s, t NI, capacity, cost WeightFunc, limit float64) (value, totalCost float64, arcFlow [][]float64, err error) {
+	a := g.LabeledAdjacencyList
+	// potentials are shortest path distances over arcs with capacity
+	w := func(l LI) float64 {
+		if !(capacity(l) > 0) {
+			return math.Inf(1)
+		}
+		return cost(l)
+	}
+	_, _, pi, end := g.BellmanFord(w, s)
+	if end >= 0 {
+		return 0, 0, nil, errors.New("negative cost cycle")
+	}
+	fn := newFlowNet(a, capacity, cost)
+	excess := make([]float64, len(a))
+	if s != t {
+		excess[s] = limit
+		excess[t] = -limit
+	}
+	value = fn.ssp(excess, pi)
+	if math.IsInf(value, 1) {
+		return value, 0, nil, errors.New("unbounded flow")
+	}
+	arcFlow = fn.arcFlow(a)
+	return value, flowCost(a, arcFlow, cost), arcFlow, nil
+}
+
+// MinCostCirculation computes a minimum cost flow satisfying node supplies
+// and demands.
+//
+// Argument supply gives the supply of each node, positive for nodes
+// supplying flow, negative for nodes demanding flow.  Supply may be nil for
+// a circulation with no supplies or demands, or a slice of length g.Order().
+// Supplies and demands must sum to zero for a feasible result.
+//
+// Arc capacities are given by WeightFunc capacity and costs per unit of flow
+// by WeightFunc cost.  Capacities must be non-negative and may be +Inf.
+// Costs may be negative.  Arcs of negative cost and infinite capacity are
+// not allowed.  Residual arcs are handled internally.
+//
+// Returned is the flow on each arc of g, indexed parallel to g, and the
+// total cost.  A non-nil error is returned if supplies and demands cannot be
+// satisfied.
+//
+// The algorithm is successive shortest paths, after first saturating arcs of
+// negative cost.
+//
+// See also MinCostFlow.
+func (g LabeledDirected) MinCostCirculation(supply []float64, capacity, cost WeightFunc) (arcFlow [][]float64, totalCost float64, err error) {
+	a := g.LabeledAdjacencyList
+	fn := newFlowNet(a, capacity, cost)
+	excess := make([]float64, len(a))
+	copy(excess, supply)
+	total := 0. // total supply, for a feasibility tolerance
+	for _, s := range excess {
+		total += math.Abs(s)
+	}
+	// saturate negative cost arcs so all residual costs are non-negative
+	for fr, to := range a {
+		for x := fn.o[fr]; x < fn.o[fr]+len(to); x++ {
+			arc := &fn.a[x]
+			if arc.cost < 0 && arc.cap > 0 {
+				if math.IsInf(arc.cap, 1) {
+					return nil, 0, errors.New("negative cost arc with infinite capacity")
+				}
+				total += 2 * arc.cap
+				excess[fr] -= arc.cap
+				excess[arc.to] += arc.cap
+				fn.push(x, arc.cap)
+			}
+		}
+	}
+	fn.ssp(excess, make([]float64, len(a)))
+	eps := 1e-9 * total
+	for _, e := range excess {
+		if math.Abs(e) > eps {
+			return nil, 0, errors.New("infeasible supplies and demands")
+		}
+	}
+	arcFlow = fn.arcFlow(a)
+	return arcFlow, flowCost(a, arcFlow, cost), nil
+}
@@ -0,0 +1,357 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/bits"
+	"github.com/soniakeys/graph"
+)
+
+func ExampleLabeledDirected_Dinic() {
+	// The classic example network of Cormen, Leiserson, and Rivest.
+	// Labels are arc capacities.
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 16}, {To: 2, Label: 13}},
+		1: {{To: 3, Label: 12}},
+		2: {{To: 1, Label: 4}, {To: 4, Label: 14}},
+		3: {{To: 2, Label: 9}, {To: 5, Label: 20}},
+		4: {{To: 3, Label: 7}, {To: 5, Label: 4}},
+		5: {},
+	}}
+	capacity := func(l graph.LI) float64 { return float64(l) }
+	f, arcFlow, cut := g.Dinic(0, 5, capacity)
+	fmt.Println("max flow:", f)
+	fmt.Println("source side of min cut:", cut.Slice())
+	for fr, to := range g.LabeledAdjacencyList {
+		for x, h := range to {
+			fmt.Printf("%d->%d  %2.0f/%d\n", fr, h.To, arcFlow[fr][x], h.Label)
+		}
+	}
+	// Output:
+	// max flow: 23
+	// source side of min cut: [0 1 2 4]
+	// 0->1  12/16
+	// 0->2  11/13
+	// 1->3  12/12
+	// 2->1   0/4
+	// 2->4  11/14
+	// 3->2   0/9
+	// 3->5  19/20
+	// 4->3   7/7
+	// 4->5   4/4
+}
+
+func ExampleLabeledDirected_PushRelabel() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 16}, {To: 2, Label: 13}},
+		1: {{To: 3, Label: 12}},
+		2: {{To: 1, Label: 4}, {To: 4, Label: 14}},
+		3: {{To: 2, Label: 9}, {To: 5, Label: 20}},
+		4: {{To: 3, Label: 7}, {To: 5, Label: 4}},
+		5: {},
+	}}
+	capacity := func(l graph.LI) float64 { return float64(l) }
+	f, _, cut := g.PushRelabel(0, 5, capacity)
+	fmt.Println("max flow:", f)
+	fmt.Println("source side of min cut:", cut.Slice())
+	// Output:
+	// max flow: 23
+	// source side of min cut: [0 1 2 4]
+}
+
+// TestMaxFlow checks Dinic and PushRelabel against each other on random
+// graphs, and checks capacity constraints, flow conservation, and that the
+// cut capacity equals the flow value.
+func TestMaxFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		nNodes := 2 + r.Intn(30)
+		g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, nNodes)}
+		for a := r.Intn(nNodes * 4); a > 0; a-- {
+			fr := r.Intn(nNodes)
+			to := graph.NI(r.Intn(nNodes))
+			g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+				graph.Half{To: to, Label: graph.LI(r.Intn(20))})
+		}
+		capacity := func(l graph.LI) float64 { return float64(l) }
+		s, tn := graph.NI(0), graph.NI(nNodes-1)
+		check := func(name string, f float64, arcFlow [][]float64, cut bits.Bits) {
+			net := make([]float64, nNodes)
+			cutCap := 0.
+			for fr, to := range g.LabeledAdjacencyList {
+				for x, h := range to {
+					af := arcFlow[fr][x]
+					if af < 0 || af > capacity(h.Label) {
+						t.Fatal(name, "capacity violated")
+					}
+					net[fr] -= af
+					net[h.To] += af
+					if cut.Bit(fr) == 1 && cut.Bit(int(h.To)) == 0 {
+						cutCap += capacity(h.Label)
+					}
+				}
+			}
+			for n, nf := range net {
+				switch graph.NI(n) {
+				case s:
+					nf = -nf
+					fallthrough
+				case tn:
+					if math.Abs(nf-f) > 1e-9 {
+						t.Fatal(name, "flow value", nf, f)
+					}
+				default:
+					if math.Abs(nf) > 1e-9 {
+						t.Fatal(name, "conservation", n, nf)
+					}
+				}
+			}
+			if cut.Bit(int(s)) != 1 || cut.Bit(int(tn)) != 0 {
+				t.Fatal(name, "cut does not separate s and t")
+			}
+			if math.Abs(cutCap-f) > 1e-9 {
+				t.Fatal(name, "cut capacity", cutCap, f)
+			}
+		}
+		df, dFlow, dCut := g.Dinic(s, tn, capacity)
+		check("Dinic", df, dFlow, dCut)
+		pf, pFlow, pCut := g.PushRelabel(s, tn, capacity)
+		check("PushRelabel", pf, pFlow, pCut)
+		if df != pf {
+			t.Fatal("Dinic", df, "PushRelabel", pf)
+		}
+	}
+	// arcs of infinite capacity, with a finite cut.  label 0 is +Inf.
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 2}},
+		1: {{To: 2, Label: 3}, {To: 3, Label: 4}},
+		2: {{To: 3, Label: 0}},
+		3: {},
+	}}
+	capacity := func(l graph.LI) float64 {
+		if l == 0 {
+			return math.Inf(1)
+		}
+		return float64(l)
+	}
+	for _, mf := range []struct {
+		name string
+		f    func(s, t graph.NI, capacity graph.WeightFunc) (float64, [][]float64, bits.Bits)
+	}{{"Dinic", g.Dinic}, {"PushRelabel", g.PushRelabel}} {
+		f, arcFlow, _ := mf.f(0, 3, capacity)
+		if f != 9 {
+			t.Fatal(mf.name, "infinite capacity flow value", f)
+		}
+		net := make([]float64, 4)
+		for fr, to := range g.LabeledAdjacencyList {
+			for x, h := range to {
+				af := arcFlow[fr][x]
+				if math.IsNaN(af) || math.IsInf(af, 0) || af < 0 {
+					t.Fatal(mf.name, "arc flow", fr, h.To, af)
+				}
+				net[fr] -= af
+				net[h.To] += af
+			}
+		}
+		if net[0] != -9 || net[1] != 0 || net[2] != 0 || net[3] != 9 {
+			t.Fatal(mf.name, "infinite capacity conservation", net)
+		}
+	}
+}
+
+func ExampleLabeledDirected_MinCostFlow() {
+	// Labels index arc data of capacity and cost.
+	type arc struct{ capacity, cost float64 }
+	arcs := []arc{
+		{4, 2}, // 0: 0->1
+		{2, 2}, // 1: 0->2
+		{2, 1}, // 2: 1->2
+		{3, 3}, // 3: 1->3
+		{5, 1}, // 4: 2->3
+	}
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 2}, {To: 3, Label: 3}},
+		2: {{To: 3, Label: 4}},
+		3: {},
+	}}
+	capacity := func(l graph.LI) float64 { return arcs[l].capacity }
+	cost := func(l graph.LI) float64 { return arcs[l].cost }
+	f, c, arcFlow, err := g.MinCostFlow(0, 3, capacity, cost, math.Inf(1))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("flow:", f)
+	fmt.Println("cost:", c)
+	for fr, to := range g.LabeledAdjacencyList {
+		for x, h := range to {
+			fmt.Printf("%d->%d  %.0f/%.0f\n", fr, h.To,
+				arcFlow[fr][x], arcs[h.Label].capacity)
+		}
+	}
+	// Output:
+	// flow: 6
+	// cost: 24
+	// 0->1  4/4
+	// 0->2  2/2
+	// 1->2  2/2
+	// 1->3  2/3
+	// 2->3  4/5
+}
+
+func ExampleLabeledDirected_MinCostFlow_limit() {
+	type arc struct{ capacity, cost float64 }
+	arcs := []arc{{4, 2}, {2, 2}, {2, 1}, {3, 3}, {5, 1}}
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 0}, {To: 2, Label: 1}},
+		1: {{To: 2, Label: 2}, {To: 3, Label: 3}},
+		2: {{To: 3, Label: 4}},
+		3: {},
+	}}
+	capacity := func(l graph.LI) float64 { return arcs[l].capacity }
+	cost := func(l graph.LI) float64 { return arcs[l].cost }
+	f, c, _, _ := g.MinCostFlow(0, 3, capacity, cost, 3)
+	fmt.Println("flow:", f)
+	fmt.Println("cost:", c)
+	// Output:
+	// flow: 3
+	// cost: 10
+}
+
+func ExampleLabeledDirected_MinCostCirculation() {
+	// Two warehouses 0 and 1 supply three stores 2, 3, and 4.
+	// Labels are shipping costs.  Capacities are unlimited.
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 2, Label: 4}, {To: 3, Label: 6}, {To: 4, Label: 9}},
+		1: {{To: 2, Label: 5}, {To: 3, Label: 3}, {To: 4, Label: 7}},
+		2: {},
+		3: {},
+		4: {},
+	}}
+	supply := []float64{30, 20, -10, -25, -15}
+	capacity := func(graph.LI) float64 { return math.Inf(1) }
+	cost := func(l graph.LI) float64 { return float64(l) }
+	arcFlow, c, err := g.MinCostCirculation(supply, capacity, cost)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("cost:", c)
+	for fr, to := range g.LabeledAdjacencyList {
+		for x, h := range to {
+			if f := arcFlow[fr][x]; f > 0 {
+				fmt.Printf("%d->%d  %.0f\n", fr, h.To, f)
+			}
+		}
+	}
+	// Output:
+	// cost: 265
+	// 0->2  10
+	// 0->3  5
+	// 0->4  15
+	// 1->3  20
+}
+
+// TestMinCostFlow checks that MinCostFlow and MinCostCirculation results
+// are feasible and optimal.  A flow is optimal if its residual graph has no
+// negative cost cycle.
+func TestMinCostFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	type arc struct{ capacity, cost float64 }
+	for i := 0; i < 100; i++ {
+		nNodes := 2 + r.Intn(20)
+		g := graph.LabeledDirected{make(graph.LabeledAdjacencyList, nNodes)}
+		var arcs []arc
+		// costs are non-negative for MinCostFlow, so no negative cycles
+		for a := r.Intn(nNodes * 4); a > 0; a-- {
+			fr := r.Intn(nNodes)
+			to := graph.NI(r.Intn(nNodes))
+			g.LabeledAdjacencyList[fr] = append(g.LabeledAdjacencyList[fr],
+				graph.Half{To: to, Label: graph.LI(len(arcs))})
+			arcs = append(arcs, arc{float64(r.Intn(10)), float64(r.Intn(10))})
+		}
+		capacity := func(l graph.LI) float64 { return arcs[l].capacity }
+		cost := func(l graph.LI) float64 { return arcs[l].cost }
+		s, tn := graph.NI(0), graph.NI(nNodes-1)
+		check := func(name string, supply []float64, arcFlow [][]float64) {
+			// residual graph labels index arcs of residual cost
+			res := make(graph.LabeledAdjacencyList, nNodes)
+			var rc []float64
+			net := make([]float64, nNodes)
+			for fr, to := range g.LabeledAdjacencyList {
+				for x, h := range to {
+					f := arcFlow[fr][x]
+					c := arcs[h.Label]
+					if f < 0 || f > c.capacity {
+						t.Fatal(name, "capacity violated")
+					}
+					net[fr] += f
+					net[h.To] -= f
+					if f < c.capacity {
+						res[fr] = append(res[fr],
+							graph.Half{To: h.To, Label: graph.LI(len(rc))})
+						rc = append(rc, c.cost)
+					}
+					if f > 0 {
+						res[h.To] = append(res[h.To],
+							graph.Half{To: graph.NI(fr), Label: graph.LI(len(rc))})
+						rc = append(rc, -c.cost)
+					}
+				}
+			}
+			for n, nf := range net {
+				if math.Abs(nf-supply[n]) > 1e-9 {
+					t.Fatal(name, "conservation", n, nf, supply[n])
+				}
+			}
+			if (graph.LabeledDirected{res}).HasNegativeCycle(func(l graph.LI) float64 { return rc[l] }) {
+				t.Fatal(name, "not optimal")
+			}
+		}
+		maxFlow, _, _ := g.Dinic(s, tn, capacity)
+		limit := float64(r.Intn(int(maxFlow) + 2))
+		f, c, arcFlow, err := g.MinCostFlow(s, tn, capacity, cost, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f != math.Min(limit, maxFlow) {
+			t.Fatal("MinCostFlow value", f, limit, maxFlow)
+		}
+		supply := make([]float64, nNodes)
+		supply[s] = f
+		supply[tn] = -f
+		check("MinCostFlow", supply, arcFlow)
+		// same supplies with MinCostCirculation should give the same cost
+		arcFlow, c2, err := g.MinCostCirculation(supply, capacity, cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check("MinCostCirculation", supply, arcFlow)
+		if math.Abs(c-c2) > 1e-9 {
+			t.Fatal("cost", c, c2)
+		}
+		// negative costs and random balanced supplies for MinCostCirculation
+		for l := range arcs {
+			arcs[l].cost -= 5
+		}
+		for n := range supply {
+			supply[n] = 0
+		}
+		for j := r.Intn(5); j > 0; j-- {
+			q := float64(r.Intn(5))
+			supply[r.Intn(nNodes)] += q
+			supply[r.Intn(nNodes)] -= q
+		}
+		arcFlow, _, err = g.MinCostCirculation(supply, capacity, cost)
+		if err == nil {
+			check("MinCostCirculation negative costs", supply, arcFlow)
+		}
+	}
+}
@@ -37,6 +37,8 @@ import (
 //  LabeledSubgraph
 //  LabeledDirectedSubgraph
 //  LabeledUndirectedSubgraph
+//  CSR
+//  LabeledCSR
 //  Edge
 //  LabeledEdge
 //  LabeledPath
@@ -70,6 +72,12 @@ import (
 //go:generate gofmt -r "n.To -> n" -w undir_RO.go
 //go:generate gofmt -r "Half -> NI" -w undir_RO.go
 
+//go:generate cp csr_cg.go csr_RO.go
+//go:generate gofmt -r "LabeledCSR -> CSR" -w csr_RO.go
+//go:generate gofmt -r "LabeledAdjacencyList -> AdjacencyList" -w csr_RO.go
+//go:generate gofmt -r "n.To -> n" -w csr_RO.go
+//go:generate gofmt -r "Half -> NI" -w csr_RO.go
+
 // NI is a "node int"
 //
 // It is a node number or node ID.  NIs are used extensively as slice indexes.
@@ -300,6 +308,35 @@ type LabeledUndirectedSubgraph struct {
 	SuperNI []NI
 }
 
+// CSR represents a graph in "compressed sparse row" form.
+//
+// Where an AdjacencyList holds a separate to-list for each node, a CSR holds
+// all arcs of the graph in the single slice Arcs.  The to-list of node n is
+// the subslice Arcs[Offsets[n]:Offsets[n+1]].  Offsets has one more element
+// than the number of nodes of the graph.  Its first element is 0 and its last
+// element is len(Arcs).
+//
+// The compact representation avoids the memory overhead of a slice header
+// for each node and keeps to-lists contiguous in memory.  It is intended to
+// be constructed once from an AdjacencyList and then used for read-only
+// queries.  See constructor AdjacencyList.CSR.
+//
+// Like AdjacencyList, CSR is inherently directed but can represent directed
+// or undirected graphs.
+type CSR struct {
+	Offsets []int // index into Arcs of the to-list of each node
+	Arcs    []NI  // to-lists of all nodes, concatenated
+}
+
+// LabeledCSR represents a labeled graph in "compressed sparse row" form.
+//
+// This is the labeled version of CSR.  See types CSR and
+// LabeledAdjacencyList.
+type LabeledCSR struct {
+	Offsets []int  // index into Arcs of the to-list of each node
+	Arcs    []Half // to-lists of all nodes, concatenated
+}
+
 // Edge is an undirected edge between nodes N1 and N2.
 type Edge struct{ N1, N2 NI }
 
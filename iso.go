@@ -0,0 +1,459 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// iso.go has graph isomorphism and subgraph isomorphism by a VF2 style
+// search.
+
+// Isomorphisms emits all isomorphisms between directed graphs g and h.
+//
+// An isomorphism is a one to one mapping of the nodes of h to the nodes of g
+// such that for each ordered pair of nodes, the number of arcs between them
+// in h equals the number of arcs between the mapped nodes in g.  The argument
+// to emit is the mapping, indexed by nodes of h, giving nodes of g.  The
+// slice is reused for subsequent calls; emit must copy it to retain it.
+// Emit must return true to continue the search or false to stop it.
+//
+// To simply test whether g and h are isomorphic, return false from emit.
+//
+// See SubgraphIsomorphisms for more on the search.
+func (g Directed) Isomorphisms(h Directed, emit func([]NI) bool) {
+	ga, ha := g.AdjacencyList, h.AdjacencyList
+	newVF2(isoExact, newIsoSide(ha, true), newIsoSide(ga, true),
+		arcCountCompat(ha, ga, true), emit).search()
+}
+
+// SubgraphIsomorphisms emits all subgraph isomorphisms from a pattern
+// directed graph p to subgraphs of g.
+//
+// A subgraph isomorphism is a one to one mapping of the nodes of p to nodes
+// of g.  If induced is false, the mapping must take each arc of p to a
+// distinct arc of g, so that the number of arcs between each ordered pair
+// of nodes of p is at most the number of arcs between the mapped nodes of g.
+// If induced is true, the numbers must be equal, so that p is isomorphic to
+// the subgraph of g induced by the mapped nodes.
+//
+// The argument to emit is the mapping, indexed by nodes of p, giving nodes
+// of g.  The slice is reused for subsequent calls; emit must copy it to
+// retain it.  Emit must return true to continue the search or false to
+// stop it.
+//
+// The search is a depth first search of partial mappings in the manner of
+// the VF2 algorithm of Cordella et al.  Nodes of p are mapped in an order
+// favoring nodes with many already mapped neighbors.  Candidate nodes of g
+// are pruned by degree, by consistency with the mapped nodes, and by counts
+// of unmapped neighbors adjacent and not adjacent to the mapping.  Worst
+// case time is exponential.
+func (g Directed) SubgraphIsomorphisms(p Directed, induced bool, emit func([]NI) bool) {
+	ga, pa := g.AdjacencyList, p.AdjacencyList
+	mode := isoMono
+	if induced {
+		mode = isoInduced
+	}
+	newVF2(mode, newIsoSide(pa, true), newIsoSide(ga, true),
+		arcCountCompat(pa, ga, induced), emit).search()
+}
+
+// Isomorphisms emits all isomorphisms between undirected graphs g and h.
+//
+// An isomorphism is a one to one mapping of the nodes of h to the nodes of g
+// such that for each pair of nodes, the number of edges between them in h
+// equals the number of edges between the mapped nodes in g.  The argument to
+// emit is the mapping, indexed by nodes of h, giving nodes of g.  The slice
+// is reused for subsequent calls; emit must copy it to retain it.  Emit must
+// return true to continue the search or false to stop it.
+//
+// To simply test whether g and h are isomorphic, return false from emit.
+//
+// See Directed.SubgraphIsomorphisms for more on the search.
+func (g Undirected) Isomorphisms(h Undirected, emit func([]NI) bool) {
+	ga, ha := g.AdjacencyList, h.AdjacencyList
+	newVF2(isoExact, newIsoSide(ha, false), newIsoSide(ga, false),
+		arcCountCompat(ha, ga, true), emit).search()
+}
+
+// SubgraphIsomorphisms emits all subgraph isomorphisms from a pattern
+// undirected graph p to subgraphs of g.
+//
+// A subgraph isomorphism is a one to one mapping of the nodes of p to nodes
+// of g.  If induced is false, the mapping must take each edge of p to a
+// distinct edge of g, so that the number of edges between each pair of nodes
+// of p is at most the number of edges between the mapped nodes of g.  If
+// induced is true, the numbers must be equal, so that p is isomorphic to the
+// subgraph of g induced by the mapped nodes.
+//
+// The argument to emit is the mapping, indexed by nodes of p, giving nodes
+// of g.  The slice is reused for subsequent calls; emit must copy it to
+// retain it.  Emit must return true to continue the search or false to
+// stop it.
+//
+// See Directed.SubgraphIsomorphisms for more on the search.
+func (g Undirected) SubgraphIsomorphisms(p Undirected, induced bool, emit func([]NI) bool) {
+	ga, pa := g.AdjacencyList, p.AdjacencyList
+	mode := isoMono
+	if induced {
+		mode = isoInduced
+	}
+	newVF2(mode, newIsoSide(pa, false), newIsoSide(ga, false),
+		arcCountCompat(pa, ga, induced), emit).search()
+}
+
+// Isomorphisms emits all isomorphisms between labeled directed graphs g
+// and h.
+//
+// Isomorphisms are as described for Directed.Isomorphisms with the
+// additional condition that arcs of h can be paired with arcs of g such
+// that match(hLabel, gLabel) is true for each pair.  If match is nil,
+// labels are ignored.
+func (g LabeledDirected) Isomorphisms(h LabeledDirected, match func(hLabel, gLabel LI) bool, emit func([]NI) bool) {
+	ga, ha := g.LabeledAdjacencyList, h.LabeledAdjacencyList
+	newVF2(isoExact, newIsoSide(ha.Unlabeled(), true),
+		newIsoSide(ga.Unlabeled(), true),
+		labelCompat(ha, ga, true, match), emit).search()
+}
+
+// SubgraphIsomorphisms emits all subgraph isomorphisms from a pattern
+// labeled directed graph p to subgraphs of g.
+//
+// Subgraph isomorphisms are as described for Directed.SubgraphIsomorphisms
+// with the additional condition that arcs of p can be paired with distinct
+// arcs of g such that match(pLabel, gLabel) is true for each pair.  If match
+// is nil, labels are ignored.
+func (g LabeledDirected) SubgraphIsomorphisms(p LabeledDirected, induced bool, match func(pLabel, gLabel LI) bool, emit func([]NI) bool) {
+	ga, pa := g.LabeledAdjacencyList, p.LabeledAdjacencyList
+	mode := isoMono
+	if induced {
+		mode = isoInduced
+	}
+	newVF2(mode, newIsoSide(pa.Unlabeled(), true),
+		newIsoSide(ga.Unlabeled(), true),
+		labelCompat(pa, ga, induced, match), emit).search()
+}
+
+// Isomorphisms emits all isomorphisms between labeled undirected graphs g
+// and h.
+//
+// Isomorphisms are as described for Undirected.Isomorphisms with the
+// additional condition that edges of h can be paired with edges of g such
+// that match(hLabel, gLabel) is true for each pair.  If match is nil,
+// labels are ignored.
+func (g LabeledUndirected) Isomorphisms(h LabeledUndirected, match func(hLabel, gLabel LI) bool, emit func([]NI) bool) {
+	ga, ha := g.LabeledAdjacencyList, h.LabeledAdjacencyList
+	newVF2(isoExact, newIsoSide(ha.Unlabeled(), false),
+		newIsoSide(ga.Unlabeled(), false),
+		labelCompat(ha, ga, true, match), emit).search()
+}
+
+// SubgraphIsomorphisms emits all subgraph isomorphisms from a pattern
+// labeled undirected graph p to subgraphs of g.
+//
+// Subgraph isomorphisms are as described for Undirected.SubgraphIsomorphisms
+// with the additional condition that edges of p can be paired with distinct
+// edges of g such that match(pLabel, gLabel) is true for each pair.  If
+// match is nil, labels are ignored.
+func (g LabeledUndirected) SubgraphIsomorphisms(p LabeledUndirected, induced bool, match func(pLabel, gLabel LI) bool, emit func([]NI) bool) {
+	ga, pa := g.LabeledAdjacencyList, p.LabeledAdjacencyList
+	mode := isoMono
+	if induced {
+		mode = isoInduced
+	}
+	newVF2(mode, newIsoSide(pa.Unlabeled(), false),
+		newIsoSide(ga.Unlabeled(), false),
+		labelCompat(pa, ga, induced, match), emit).search()
+}
+
+// arcCountCompat returns a function comparing the number of arcs u->v in
+// pattern p to the number of arcs x->y in target t.  The numbers must be
+// equal if exact is true, otherwise the pattern number must be no more than
+// the target number.
+func arcCountCompat(p, t AdjacencyList, exact bool) func(u, v, x, y NI) bool {
+	return func(u, v, x, y NI) bool {
+		c := 0
+		for _, to := range p[u] {
+			if to == v {
+				c++
+			}
+		}
+		for _, to := range t[x] {
+			if to == y {
+				c--
+			}
+		}
+		return c == 0 || c < 0 && !exact
+	}
+}
+
+// labelCompat returns a function like that of arcCountCompat but that also
+// requires the labels of arcs u->v in p to be matched to distinct labels of
+// arcs x->y in t.
+func labelCompat(p, t LabeledAdjacencyList, exact bool, match func(pLabel, tLabel LI) bool) func(u, v, x, y NI) bool {
+	return func(u, v, x, y NI) bool {
+		var pl, tl []LI
+		for _, h := range p[u] {
+			if h.To == v {
+				pl = append(pl, h.Label)
+			}
+		}
+		for _, h := range t[x] {
+			if h.To == y {
+				tl = append(tl, h.Label)
+			}
+		}
+		if len(pl) > len(tl) || exact && len(pl) < len(tl) {
+			return false
+		}
+		if match == nil || len(pl) == 0 {
+			return true
+		}
+		// bipartite matching of pattern labels to target labels by
+		// augmenting paths.  parallel arcs are usually few.
+		mt := make([]int, len(tl)) // pattern label index matched, +1
+		var seen []bool
+		var augment func(i int) bool
+		augment = func(i int) bool {
+			for j, l := range tl {
+				if seen[j] || !match(pl[i], l) {
+					continue
+				}
+				seen[j] = true
+				if mt[j] == 0 || augment(mt[j]-1) {
+					mt[j] = i + 1
+					return true
+				}
+			}
+			return false
+		}
+		for i := range pl {
+			seen = make([]bool, len(tl))
+			if !augment(i) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+const (
+	isoExact   = iota // isomorphism
+	isoInduced        // induced subgraph isomorphism
+	isoMono           // subgraph isomorphism, or monomorphism
+)
+
+// isoSide holds a graph for vf2, either the pattern or the target.
+type isoSide struct {
+	a   AdjacencyList
+	in  []int  // in-degree, nil for undirected graphs
+	nbr [][]NI // distinct neighbors, ignoring arc direction and loops
+	cnt []int  // number of mapped neighbors
+}
+
+func newIsoSide(a AdjacencyList, directed bool) *isoSide {
+	s := &isoSide{
+		a:   a,
+		nbr: make([][]NI, len(a)),
+		cnt: make([]int, len(a)),
+	}
+	seen := make([]int, len(a))
+	add := func(fr, to NI) {
+		if to != fr && seen[to] != int(fr)+1 {
+			seen[to] = int(fr) + 1
+			s.nbr[fr] = append(s.nbr[fr], to)
+		}
+	}
+	var t AdjacencyList
+	if directed {
+		s.in = Directed{a}.InDegree()
+		tr, _ := Directed{a}.Transpose()
+		t = tr.AdjacencyList
+	}
+	for fr, to := range a {
+		for _, to := range to {
+			add(NI(fr), to)
+		}
+		if directed {
+			for _, to := range t[fr] {
+				add(NI(fr), to)
+			}
+		}
+	}
+	return s
+}
+
+// degreeOK compares degrees of pattern node u and target node x.
+func (vf *vf2) degreeOK(u, x NI) bool {
+	p, t := vf.p, vf.t
+	if vf.mode == isoExact {
+		return len(p.a[u]) == len(t.a[x]) && len(p.nbr[u]) == len(t.nbr[x]) &&
+			(p.in == nil || p.in[u] == t.in[x])
+	}
+	return len(p.a[u]) <= len(t.a[x]) && len(p.nbr[u]) <= len(t.nbr[x]) &&
+		(p.in == nil || p.in[u] <= t.in[x])
+}
+
+// vf2 holds state for an isomorphism search, mapping nodes of pattern p to
+// nodes of target t.
+type vf2 struct {
+	mode   int
+	p, t   *isoSide
+	compat func(u, v, x, y NI) bool
+	emit   func([]NI) bool
+	m      []NI // mapping from pattern to target, -1 for unmapped
+	r      []NI // reverse mapping
+	order  []NI // order of pattern nodes to map
+	anchor []NI // a neighbor of each pattern node earlier in order, or -1
+}
+
+func newVF2(mode int, p, t *isoSide, compat func(u, v, x, y NI) bool, emit func([]NI) bool) *vf2 {
+	vf := &vf2{
+		mode:   mode,
+		p:      p,
+		t:      t,
+		compat: compat,
+		emit:   emit,
+		m:      make([]NI, len(p.a)),
+		r:      make([]NI, len(t.a)),
+		anchor: make([]NI, len(p.a)),
+	}
+	for i := range vf.m {
+		vf.m[i] = -1
+		vf.anchor[i] = -1
+	}
+	for i := range vf.r {
+		vf.r[i] = -1
+	}
+	// order pattern nodes by number of neighbors already ordered, then
+	// by degree.
+	n := len(p.a)
+	ordered := make([]bool, n)
+	cnt := make([]int, n)
+	for len(vf.order) < n {
+		best := NI(-1)
+		for u := range p.a {
+			if ordered[u] {
+				continue
+			}
+			if best < 0 || cnt[u] > cnt[best] ||
+				cnt[u] == cnt[best] && len(p.nbr[u]) > len(p.nbr[best]) {
+				best = NI(u)
+			}
+		}
+		ordered[best] = true
+		vf.order = append(vf.order, best)
+		for _, w := range p.nbr[best] {
+			if !ordered[w] && vf.anchor[w] < 0 {
+				vf.anchor[w] = best
+			}
+			cnt[w]++
+		}
+	}
+	return vf
+}
+
+// search runs the search.
+func (vf *vf2) search() {
+	if vf.mode == isoExact {
+		if len(vf.p.a) != len(vf.t.a) ||
+			vf.p.a.ArcSize() != vf.t.a.ArcSize() {
+			return
+		}
+	} else if len(vf.p.a) > len(vf.t.a) {
+		return
+	}
+	vf.match(0)
+}
+
+// match extends the mapping with the pattern node at position i in the
+// order.  It returns false if emit returned false.
+func (vf *vf2) match(i int) bool {
+	if i == len(vf.order) {
+		return vf.emit(vf.m)
+	}
+	u := vf.order[i]
+	try := func(x NI) bool {
+		if vf.r[x] >= 0 || !vf.feasible(u, x) {
+			return true
+		}
+		vf.set(u, x, 1)
+		ok := vf.match(i + 1)
+		vf.set(u, x, -1)
+		return ok
+	}
+	if a := vf.anchor[u]; a >= 0 {
+		for _, x := range vf.t.nbr[vf.m[a]] {
+			if !try(x) {
+				return false
+			}
+		}
+		return true
+	}
+	for x := range vf.t.a {
+		if !try(NI(x)) {
+			return false
+		}
+	}
+	return true
+}
+
+// set maps u to x for d = 1 or unmaps them for d = -1, updating mapped
+// neighbor counts.
+func (vf *vf2) set(u, x NI, d int) {
+	if d > 0 {
+		vf.m[u], vf.r[x] = x, u
+	} else {
+		vf.m[u], vf.r[x] = -1, -1
+	}
+	for _, w := range vf.p.nbr[u] {
+		vf.p.cnt[w] += d
+	}
+	for _, y := range vf.t.nbr[x] {
+		vf.t.cnt[y] += d
+	}
+}
+
+// feasible tests whether pattern node u can be mapped to target node x.
+func (vf *vf2) feasible(u, x NI) bool {
+	p, t := vf.p, vf.t
+	directed := p.in != nil
+	if !vf.degreeOK(u, x) || !vf.compat(u, u, x, x) {
+		return false
+	}
+	// consistency with mapped neighbors, and count terminal and new
+	// unmapped neighbors.
+	pTerm, pNew := 0, 0
+	for _, w := range p.nbr[u] {
+		switch y := vf.m[w]; {
+		case y >= 0:
+			if !vf.compat(u, w, x, y) || directed && !vf.compat(w, u, y, x) {
+				return false
+			}
+		case p.cnt[w] > 0:
+			pTerm++
+		default:
+			pNew++
+		}
+	}
+	tTerm, tNew := 0, 0
+	for _, y := range t.nbr[x] {
+		switch w := vf.r[y]; {
+		case w >= 0:
+			// a mapped target neighbor must have a pattern neighbor
+			// unless extra target arcs are allowed.
+			if vf.mode != isoMono && (!vf.compat(u, w, x, y) ||
+				directed && !vf.compat(w, u, y, x)) {
+				return false
+			}
+		case t.cnt[y] > 0:
+			tTerm++
+		default:
+			tNew++
+		}
+	}
+	switch vf.mode {
+	case isoExact:
+		return pTerm == tTerm && pNew == tNew
+	case isoInduced:
+		return pTerm <= tTerm && pNew <= tNew
+	}
+	return pTerm <= tTerm && pTerm+pNew <= tTerm+tNew
+}
@@ -0,0 +1,241 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleUndirected_Isomorphisms() {
+	// 0--1     0--2
+	// |  |     |  |
+	// 3--2     1--3
+	var g, h graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 0)
+	h.AddEdge(0, 2)
+	h.AddEdge(2, 3)
+	h.AddEdge(3, 1)
+	h.AddEdge(1, 0)
+	g.Isomorphisms(h, func(m []graph.NI) bool {
+		fmt.Println(m)
+		return false
+	})
+	// Output:
+	// [0 1 3 2]
+}
+
+func ExampleDirected_SubgraphIsomorphisms() {
+	// Find directed triangles.
+	// 0-->1-->2
+	// ^  /^   |
+	// | v  \  v
+	// 3<----4<-
+	g := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {2, 3},
+		2: {4},
+		3: {0},
+		4: {1, 3},
+	}}
+	p := graph.Directed{graph.AdjacencyList{
+		0: {1},
+		1: {2},
+		2: {0},
+	}}
+	g.SubgraphIsomorphisms(p, false, func(m []graph.NI) bool {
+		fmt.Println(m)
+		return true
+	})
+	// Output:
+	// [0 1 3]
+	// [1 2 4]
+	// [1 3 0]
+	// [2 4 1]
+	// [3 0 1]
+	// [4 1 2]
+}
+
+func ExampleLabeledUndirected_SubgraphIsomorphisms() {
+	// Labels are bond types in a molecule-like graph: 1 single, 2 double.
+	// Find a double bond adjacent to a single bond.
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 1)
+	g.AddEdge(graph.Edge{1, 2}, 2)
+	g.AddEdge(graph.Edge{2, 3}, 1)
+	g.AddEdge(graph.Edge{3, 4}, 1)
+	var p graph.LabeledUndirected
+	p.AddEdge(graph.Edge{0, 1}, 2)
+	p.AddEdge(graph.Edge{1, 2}, 1)
+	match := func(pLabel, gLabel graph.LI) bool { return pLabel == gLabel }
+	g.SubgraphIsomorphisms(p, false, match, func(m []graph.NI) bool {
+		fmt.Println(m)
+		return true
+	})
+	// Output:
+	// [2 1 0]
+	// [1 2 3]
+}
+
+func ExampleLabeledDirected_Isomorphisms() {
+	g := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 5}},
+		1: {{To: 2, Label: 7}},
+		2: {{To: 0, Label: 5}},
+	}}
+	h := graph.LabeledDirected{graph.LabeledAdjacencyList{
+		0: {{To: 1, Label: 5}},
+		1: {{To: 2, Label: 5}},
+		2: {{To: 0, Label: 7}},
+	}}
+	// without labels, all rotations
+	g.Isomorphisms(h, nil, func(m []graph.NI) bool {
+		fmt.Println(m)
+		return true
+	})
+	// with labels
+	fmt.Println("matching labels:")
+	g.Isomorphisms(h, func(hl, gl graph.LI) bool { return hl == gl },
+		func(m []graph.NI) bool {
+			fmt.Println(m)
+			return true
+		})
+	// Output:
+	// [0 1 2]
+	// [1 2 0]
+	// [2 0 1]
+	// matching labels:
+	// [2 0 1]
+}
+
+// bruteIso counts one to one mappings m from pattern p to g such that arc
+// counts are equal, or for !exact, no greater in p.
+func bruteIso(g, p graph.AdjacencyList, exact bool) (n int) {
+	count := func(a graph.AdjacencyList, fr, to graph.NI) (c int) {
+		for _, t := range a[fr] {
+			if t == to {
+				c++
+			}
+		}
+		return
+	}
+	m := make([]graph.NI, len(p))
+	used := make([]bool, len(g))
+	var f func(int)
+	f = func(i int) {
+		if i == len(p) {
+			for u := range p {
+				for v := range p {
+					pc := count(p, graph.NI(u), graph.NI(v))
+					gc := count(g, m[u], m[v])
+					if pc > gc || exact && pc != gc {
+						return
+					}
+				}
+			}
+			n++
+			return
+		}
+		for x := range g {
+			if !used[x] {
+				used[x] = true
+				m[i] = graph.NI(x)
+				f(i + 1)
+				used[x] = false
+			}
+		}
+	}
+	f(0)
+	return
+}
+
+// TestIsomorphisms compares counts of isomorphisms and subgraph
+// isomorphisms to brute force counts on small random graphs.
+func TestIsomorphisms(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	permuted := func(a graph.AdjacencyList) graph.AdjacencyList {
+		perm := r.Perm(len(a))
+		b := make(graph.AdjacencyList, len(a))
+		for fr, to := range a {
+			for _, to := range to {
+				b[perm[fr]] = append(b[perm[fr]], graph.NI(perm[to]))
+			}
+		}
+		for _, to := range b {
+			r.Shuffle(len(to), func(i, j int) { to[i], to[j] = to[j], to[i] })
+		}
+		return b
+	}
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(6)
+		// undirected, with occasional loops and parallel edges
+		g := graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r)
+		if r.Intn(3) == 0 {
+			g.AddEdge(graph.NI(r.Intn(n)), graph.NI(r.Intn(n)))
+		}
+		h := graph.Undirected{permuted(g.AdjacencyList)}
+		got := 0
+		g.Isomorphisms(h, func(m []graph.NI) bool {
+			got++
+			return true
+		})
+		if want := bruteIso(g.AdjacencyList, h.AdjacencyList, true); got != want {
+			t.Fatal("undirected isomorphisms", got, "want", want)
+		}
+		pn := 1 + r.Intn(n)
+		p := graph.GnmUndirected(pn, r.Intn(pn*(pn-1)/2+1), r)
+		for _, induced := range []bool{false, true} {
+			got := 0
+			g.SubgraphIsomorphisms(p, induced, func(m []graph.NI) bool {
+				got++
+				return true
+			})
+			want := bruteIso(g.AdjacencyList, p.AdjacencyList, induced)
+			if got != want {
+				t.Fatal("undirected subgraph isomorphisms", induced,
+					got, "want", want)
+			}
+		}
+		// directed
+		d := graph.Directed{make(graph.AdjacencyList, n)}
+		for a := r.Intn(n * n); a > 0; a-- {
+			fr := r.Intn(n)
+			d.AdjacencyList[fr] = append(d.AdjacencyList[fr],
+				graph.NI(r.Intn(n)))
+		}
+		e := graph.Directed{permuted(d.AdjacencyList)}
+		got = 0
+		d.Isomorphisms(e, func(m []graph.NI) bool {
+			got++
+			return true
+		})
+		if want := bruteIso(d.AdjacencyList, e.AdjacencyList, true); got != want {
+			t.Fatal("directed isomorphisms", got, "want", want)
+		}
+		dp := graph.Directed{make(graph.AdjacencyList, pn)}
+		for a := r.Intn(pn * 2); a > 0; a-- {
+			fr := r.Intn(pn)
+			dp.AdjacencyList[fr] = append(dp.AdjacencyList[fr],
+				graph.NI(r.Intn(pn)))
+		}
+		for _, induced := range []bool{false, true} {
+			got := 0
+			d.SubgraphIsomorphisms(dp, induced, func(m []graph.NI) bool {
+				got++
+				return true
+			})
+			want := bruteIso(d.AdjacencyList, dp.AdjacencyList, induced)
+			if got != want {
+				t.Fatal("directed subgraph isomorphisms", induced,
+					got, "want", want)
+			}
+		}
+	}
+}
@@ -0,0 +1,497 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// planar.go has planarity testing by the left-right algorithm.
+
+// Planar tests whether an undirected graph is planar.
+//
+// If g is planar, planar is true and rotation is a rotation system for a
+// planar embedding of g.  Rotation has the same arcs as g but the arc list
+// of each node is reordered to list neighbors in clockwise order around
+// the node.  Parallel edges are listed consecutively.  Loops are listed
+// last.  Graph g is not modified.
+//
+// If g is not planar, planar is false and kuratowski is a subgraph of g that
+// is a subdivision of K5 or K3,3.  The subgraph has g as its supergraph.
+// Subgraph nodes are mapped in numerical order of supergraph nodes.
+//
+// Planarity is tested by the left-right algorithm of de Fraysseix and
+// Rosenstiehl as described by Brandes.  The test and embedding are O(V + E).
+// The Kuratowski subgraph is found by deleting edges that are not needed for
+// non-planarity, with a planarity test for each edge, and so is O(E(V + E)).
+func (g *Undirected) Planar() (planar bool, rotation Undirected, kuratowski *UndirectedSubgraph) {
+	a := g.AdjacencyList
+	// simple graph, without loops or parallel edges
+	simple := make(AdjacencyList, len(a))
+	seen := make([]int, len(a))
+	for fr, to := range a {
+		for _, to := range to {
+			if to != NI(fr) && seen[to] != fr+1 {
+				seen[to] = fr + 1
+				simple[fr] = append(simple[fr], to)
+			}
+		}
+	}
+	lr := newLRPlanarity(simple)
+	if lr.test() {
+		return true, Undirected{g.rotation(lr.embed())}, nil
+	}
+	// delete edges not needed for non-planarity
+	var edges []Edge
+	for fr, to := range simple {
+		for _, to := range to {
+			if NI(fr) < to {
+				edges = append(edges, Edge{NI(fr), to})
+			}
+		}
+	}
+	keep := edges[:0]
+	for i, e := range edges {
+		sub := make(AdjacencyList, len(a))
+		for _, e := range append(keep[:len(keep):len(keep)], edges[i+1:]...) {
+			sub[e.N1] = append(sub[e.N1], e.N2)
+			sub[e.N2] = append(sub[e.N2], e.N1)
+		}
+		if newLRPlanarity(sub).test() {
+			keep = append(keep, e)
+		}
+	}
+	inSub := make([]bool, len(a))
+	for _, e := range keep {
+		inSub[e.N1] = true
+		inSub[e.N2] = true
+	}
+	kuratowski = &UndirectedSubgraph{Super: g, SubNI: map[NI]NI{}}
+	for n, in := range inSub {
+		if in {
+			kuratowski.AddNode(NI(n))
+		}
+	}
+	for _, e := range keep {
+		kuratowski.AddEdge(e.N1, e.N2)
+	}
+	return false, Undirected{}, kuratowski
+}
+
+// rotation orders the arcs of g by the rotation system of simple graph r
+// with the same edges.
+func (g *Undirected) rotation(r AdjacencyList) AdjacencyList {
+	a := g.AdjacencyList
+	rot := make(AdjacencyList, len(a))
+	count := make([]int, len(a))
+	for fr, to := range a {
+		for _, to := range to {
+			count[to]++
+		}
+		for _, to := range r[fr] {
+			for ; count[to] > 0; count[to]-- {
+				rot[fr] = append(rot[fr], to)
+			}
+		}
+		for ; count[fr] > 0; count[fr]-- {
+			rot[fr] = append(rot[fr], NI(fr))
+		}
+	}
+	return rot
+}
+
+// lrPlanarity holds state for the left-right planarity test on a simple
+// graph.
+//
+// Edges are oriented by a depth first search.  Oriented edges are identified
+// by index into fr and to.  Embedding uses half edges where half edge 2e is
+// edge e at node fr[e] and half edge 2e+1 is edge e at node to[e].
+type lrPlanarity struct {
+	a          AdjacencyList
+	height     []int
+	parentEdge []int
+	roots      []NI
+	// per oriented edge
+	fr, to     []NI
+	out        [][]int // oriented edges out of each node
+	lowpt      []int
+	lowpt2     []int
+	nesting    []int
+	ref        []int
+	side       []int
+	lowptEdge  []int
+	stackBtm   []int
+	stack      []lrConflictPair
+	cw, ccw    []int // half edge rotation
+	first      []int // first half edge at each node
+	lRef, rRef []int // half edges at each node
+}
+
+// lrInterval is an interval of return edges, from low to high.  -1 means no
+// edge.
+type lrInterval struct{ low, high int }
+
+func (i lrInterval) empty() bool { return i.low < 0 && i.high < 0 }
+
+// lrConflictPair is a pair of intervals of return edges that must be
+// embedded on opposite sides.
+type lrConflictPair struct{ l, r lrInterval }
+
+// newLRPlanarity constructs an lrPlanarity for simple graph a and orients
+// its edges.
+func newLRPlanarity(a AdjacencyList) *lrPlanarity {
+	n := len(a)
+	lr := &lrPlanarity{
+		a:          a,
+		height:     make([]int, n),
+		parentEdge: make([]int, n),
+		out:        make([][]int, n),
+	}
+	for v := range a {
+		lr.height[v] = -1
+		lr.parentEdge[v] = -1
+	}
+	for v := range a {
+		if lr.height[v] < 0 {
+			lr.height[v] = 0
+			lr.roots = append(lr.roots, NI(v))
+			lr.orient(NI(v))
+		}
+	}
+	return lr
+}
+
+// orient orients edges of the depth first search tree from node v and
+// computes lowpoints and nesting depths.
+func (lr *lrPlanarity) orient(v NI) {
+	e := lr.parentEdge[v]
+	for _, w := range lr.a[v] {
+		if lr.height[w] >= 0 &&
+			(lr.height[w] > lr.height[v] || e >= 0 && lr.fr[e] == w) {
+			continue // edge already oriented
+		}
+		ei := len(lr.fr)
+		lr.fr = append(lr.fr, v)
+		lr.to = append(lr.to, w)
+		lr.out[v] = append(lr.out[v], ei)
+		lr.lowpt = append(lr.lowpt, lr.height[v])
+		lr.lowpt2 = append(lr.lowpt2, lr.height[v])
+		lr.nesting = append(lr.nesting, 0)
+		if lr.height[w] < 0 { // tree edge
+			lr.parentEdge[w] = ei
+			lr.height[w] = lr.height[v] + 1
+			lr.orient(w)
+		} else { // back edge
+			lr.lowpt[ei] = lr.height[w]
+		}
+		lr.nesting[ei] = 2 * lr.lowpt[ei]
+		if lr.lowpt2[ei] < lr.height[v] {
+			lr.nesting[ei]++ // chordal
+		}
+		if e >= 0 {
+			switch {
+			case lr.lowpt[ei] < lr.lowpt[e]:
+				lr.lowpt2[e] = min(lr.lowpt[e], lr.lowpt2[ei])
+				lr.lowpt[e] = lr.lowpt[ei]
+			case lr.lowpt[ei] > lr.lowpt[e]:
+				lr.lowpt2[e] = min(lr.lowpt2[e], lr.lowpt[ei])
+			default:
+				lr.lowpt2[e] = min(lr.lowpt2[e], lr.lowpt2[ei])
+			}
+		}
+	}
+}
+
+// test runs the testing phase, returning true if the graph is planar.
+func (lr *lrPlanarity) test() bool {
+	n := len(lr.a)
+	if n > 2 && len(lr.fr) > 3*n-6 {
+		return false
+	}
+	m := len(lr.fr)
+	lr.ref = make([]int, m)
+	lr.side = make([]int, m)
+	lr.lowptEdge = make([]int, m)
+	lr.stackBtm = make([]int, m)
+	for e := range lr.ref {
+		lr.ref[e] = -1
+		lr.side[e] = 1
+	}
+	lr.sortOut()
+	for _, v := range lr.roots {
+		if !lr.testFrom(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortOut sorts the out edges of each node by nesting depth.  It is a
+// bucket sort, as nesting depths are in the range -2n-1 to 2n+1.
+func (lr *lrPlanarity) sortOut() {
+	off := 2*len(lr.a) + 1
+	bucket := make([][]int, 2*off+1)
+	for e, d := range lr.nesting {
+		bucket[d+off] = append(bucket[d+off], e)
+	}
+	for v := range lr.out {
+		lr.out[v] = lr.out[v][:0]
+	}
+	for _, b := range bucket {
+		for _, e := range b {
+			lr.out[lr.fr[e]] = append(lr.out[lr.fr[e]], e)
+		}
+	}
+}
+
+func (lr *lrPlanarity) testFrom(v NI) bool {
+	e := lr.parentEdge[v]
+	for i, ei := range lr.out[v] {
+		w := lr.to[ei]
+		lr.stackBtm[ei] = len(lr.stack)
+		if ei == lr.parentEdge[w] { // tree edge
+			if !lr.testFrom(w) {
+				return false
+			}
+		} else { // back edge
+			lr.lowptEdge[ei] = ei
+			lr.stack = append(lr.stack,
+				lrConflictPair{lrInterval{-1, -1}, lrInterval{ei, ei}})
+		}
+		// integrate new return edges
+		if lr.lowpt[ei] < lr.height[v] {
+			if i == 0 {
+				lr.lowptEdge[e] = lr.lowptEdge[ei]
+			} else if !lr.addConstraints(ei, e) {
+				return false
+			}
+		}
+	}
+	if e >= 0 {
+		lr.removeBackEdges(e)
+	}
+	return true
+}
+
+func (lr *lrPlanarity) conflicting(i lrInterval, b int) bool {
+	return !i.empty() && lr.lowpt[i.high] > lr.lowpt[b]
+}
+
+func (lr *lrPlanarity) lowest(p lrConflictPair) int {
+	switch {
+	case p.l.empty():
+		return lr.lowpt[p.r.low]
+	case p.r.empty():
+		return lr.lowpt[p.l.low]
+	}
+	return min(lr.lowpt[p.l.low], lr.lowpt[p.r.low])
+}
+
+func (lr *lrPlanarity) pop() (p lrConflictPair) {
+	last := len(lr.stack) - 1
+	p = lr.stack[last]
+	lr.stack = lr.stack[:last]
+	return
+}
+
+func (lr *lrPlanarity) addConstraints(ei, e int) bool {
+	p := lrConflictPair{lrInterval{-1, -1}, lrInterval{-1, -1}}
+	// merge return edges of ei into p.r
+	for {
+		q := lr.pop()
+		if !q.l.empty() {
+			q.l, q.r = q.r, q.l
+		}
+		if !q.l.empty() {
+			return false
+		}
+		if lr.lowpt[q.r.low] > lr.lowpt[e] { // merge intervals
+			if p.r.empty() {
+				p.r = q.r
+			} else {
+				lr.ref[p.r.low] = q.r.high
+			}
+			p.r.low = q.r.low
+		} else { // align
+			lr.ref[q.r.low] = lr.lowptEdge[e]
+		}
+		if len(lr.stack) == lr.stackBtm[ei] {
+			break
+		}
+	}
+	// merge conflicting return edges of earlier out edges into p.l
+	for len(lr.stack) > 0 {
+		q := lr.stack[len(lr.stack)-1]
+		if !lr.conflicting(q.l, ei) && !lr.conflicting(q.r, ei) {
+			break
+		}
+		lr.pop()
+		if lr.conflicting(q.r, ei) {
+			q.l, q.r = q.r, q.l
+		}
+		if lr.conflicting(q.r, ei) {
+			return false
+		}
+		// merge interval below lowpt(ei) into p.r
+		if p.r.low >= 0 {
+			lr.ref[p.r.low] = q.r.high
+		}
+		if q.r.low >= 0 {
+			p.r.low = q.r.low
+		}
+		if p.l.empty() {
+			p.l = q.l
+		} else {
+			lr.ref[p.l.low] = q.l.high
+		}
+		p.l.low = q.l.low
+	}
+	if !p.l.empty() || !p.r.empty() {
+		lr.stack = append(lr.stack, p)
+	}
+	return true
+}
+
+func (lr *lrPlanarity) removeBackEdges(e int) {
+	u := lr.fr[e]
+	// trim back edges ending at parent u
+	for len(lr.stack) > 0 &&
+		lr.lowest(lr.stack[len(lr.stack)-1]) == lr.height[u] {
+		if p := lr.pop(); p.l.low >= 0 {
+			lr.side[p.l.low] = -1
+		}
+	}
+	if len(lr.stack) > 0 { // one more conflict pair to consider
+		p := &lr.stack[len(lr.stack)-1]
+		// trim left interval
+		for p.l.high >= 0 && lr.to[p.l.high] == u {
+			p.l.high = lr.ref[p.l.high]
+		}
+		if p.l.high < 0 && p.l.low >= 0 { // just emptied
+			lr.ref[p.l.low] = p.r.low
+			lr.side[p.l.low] = -1
+			p.l.low = -1
+		}
+		// trim right interval
+		for p.r.high >= 0 && lr.to[p.r.high] == u {
+			p.r.high = lr.ref[p.r.high]
+		}
+		if p.r.high < 0 && p.r.low >= 0 {
+			lr.ref[p.r.low] = p.l.low
+			lr.side[p.r.low] = -1
+			p.r.low = -1
+		}
+	}
+	// side of e is side of a highest return edge
+	if lr.lowpt[e] < lr.height[u] {
+		p := lr.stack[len(lr.stack)-1]
+		hl, hr := p.l.high, p.r.high
+		if hl >= 0 && (hr < 0 || lr.lowpt[hl] > lr.lowpt[hr]) {
+			lr.ref[e] = hl
+		} else {
+			lr.ref[e] = hr
+		}
+	}
+}
+
+// sign resolves the side of edge e relative to its reference edges.
+func (lr *lrPlanarity) sign(e int) int {
+	if r := lr.ref[e]; r >= 0 {
+		lr.side[e] *= lr.sign(r)
+		lr.ref[e] = -1
+	}
+	return lr.side[e]
+}
+
+// embed runs the embedding phase after a successful test, returning the
+// rotation system as an adjacency list.
+func (lr *lrPlanarity) embed() AdjacencyList {
+	for e := range lr.nesting {
+		lr.nesting[e] *= lr.sign(e)
+	}
+	lr.sortOut()
+	n := len(lr.a)
+	lr.cw = make([]int, 2*len(lr.fr))
+	lr.ccw = make([]int, 2*len(lr.fr))
+	lr.first = make([]int, n)
+	lr.lRef = make([]int, n)
+	lr.rRef = make([]int, n)
+	for v := range lr.first {
+		lr.first[v] = -1
+	}
+	for v, out := range lr.out {
+		prev := -1
+		for _, e := range out {
+			lr.addCW(NI(v), 2*e, prev)
+			prev = 2 * e
+		}
+	}
+	for _, v := range lr.roots {
+		lr.embedFrom(v)
+	}
+	rot := make(AdjacencyList, n)
+	for v, f := range lr.first {
+		if f < 0 {
+			continue
+		}
+		h := f
+		for {
+			rot[v] = append(rot[v], lr.halfTo(h))
+			if h = lr.cw[h]; h == f {
+				break
+			}
+		}
+	}
+	return rot
+}
+
+func (lr *lrPlanarity) embedFrom(v NI) {
+	for _, e := range lr.out[v] {
+		w := lr.to[e]
+		if e == lr.parentEdge[w] { // tree edge
+			lr.addFirst(w, 2*e+1)
+			lr.lRef[v] = 2 * e
+			lr.rRef[v] = 2 * e
+			lr.embedFrom(w)
+		} else if lr.side[e] == 1 { // back edge
+			lr.addCW(w, 2*e+1, lr.rRef[w])
+		} else {
+			lr.addCCW(w, 2*e+1, lr.lRef[w])
+			lr.lRef[w] = 2*e + 1
+		}
+	}
+}
+
+// halfTo returns the node at the far end of half edge h.
+func (lr *lrPlanarity) halfTo(h int) NI {
+	if h&1 == 0 {
+		return lr.to[h/2]
+	}
+	return lr.fr[h/2]
+}
+
+// addCW adds half edge h at node v, clockwise from half edge ref.
+func (lr *lrPlanarity) addCW(v NI, h, ref int) {
+	if ref < 0 {
+		lr.cw[h], lr.ccw[h] = h, h
+		lr.first[v] = h
+		return
+	}
+	next := lr.cw[ref]
+	lr.cw[ref], lr.ccw[h] = h, ref
+	lr.cw[h], lr.ccw[next] = next, h
+}
+
+// addCCW adds half edge h at node v, counterclockwise from half edge ref.
+func (lr *lrPlanarity) addCCW(v NI, h, ref int) {
+	if ref < 0 {
+		lr.addCW(v, h, -1)
+		return
+	}
+	lr.addCW(v, h, lr.ccw[ref])
+	if ref == lr.first[v] {
+		lr.first[v] = h
+	}
+}
+
+// addFirst adds half edge h as the first half edge at node v.
+func (lr *lrPlanarity) addFirst(v NI, h int) {
+	lr.addCCW(v, h, lr.first[v])
+}
@@ -0,0 +1,254 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleUndirected_Planar() {
+	// A cube, with a diagonal on one face.
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 0)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 6)
+	g.AddEdge(6, 7)
+	g.AddEdge(7, 4)
+	g.AddEdge(0, 4)
+	g.AddEdge(1, 5)
+	g.AddEdge(2, 6)
+	g.AddEdge(3, 7)
+	g.AddEdge(0, 2)
+	planar, rotation, _ := g.Planar()
+	fmt.Println("planar:", planar)
+	for n, to := range rotation.AdjacencyList {
+		fmt.Println(n, to)
+	}
+	// Output:
+	// planar: true
+	// 0 [1 4 3 2]
+	// 1 [0 2 5]
+	// 2 [1 0 3 6]
+	// 3 [2 0 7]
+	// 4 [5 7 0]
+	// 5 [6 4 1]
+	// 6 [7 5 2]
+	// 7 [3 4 6]
+}
+
+func ExampleUndirected_Planar_kuratowski() {
+	// The Petersen graph.
+	var g graph.Undirected
+	for i := graph.NI(0); i < 5; i++ {
+		g.AddEdge(i, (i+1)%5)     // outer cycle
+		g.AddEdge(i, i+5)         // spoke
+		g.AddEdge(i+5, (i+2)%5+5) // inner star
+	}
+	planar, _, k := g.Planar()
+	fmt.Println("planar:", planar)
+	fmt.Println("Kuratowski subgraph nodes:", k.SuperNI)
+	k.Edges(func(e graph.Edge) {
+		fmt.Println(k.SuperNI[e.N1], k.SuperNI[e.N2])
+	})
+	// Output:
+	// planar: false
+	// Kuratowski subgraph nodes: [1 2 3 4 5 6 7 8 9]
+	// 2 1
+	// 3 2
+	// 4 3
+	// 6 1
+	// 7 2
+	// 7 5
+	// 8 3
+	// 8 5
+	// 8 6
+	// 9 4
+	// 9 6
+	// 9 7
+}
+
+// faces counts the faces of the embedding given by rotation system r.
+func faces(r graph.AdjacencyList) int {
+	// pos[v][w] is the position of w in the rotation at v
+	pos := make([]map[graph.NI]int, len(r))
+	for v, to := range r {
+		pos[v] = map[graph.NI]int{}
+		for i, w := range to {
+			pos[v][w] = i
+		}
+	}
+	type half struct{ fr, to graph.NI }
+	done := map[half]bool{}
+	f := 0
+	for v, to := range r {
+		for _, w := range to {
+			h := half{graph.NI(v), w}
+			if done[h] {
+				continue
+			}
+			f++
+			for !done[h] {
+				done[h] = true
+				// next half edge of the face leaves h.to clockwise after
+				// h.fr.
+				rt := r[h.to]
+				h = half{h.to, rt[(pos[h.to][h.fr]+1)%len(rt)]}
+			}
+		}
+	}
+	return f
+}
+
+// kuratowski tests that a graph is a subdivision of K5 or K3,3.
+func kuratowski(g graph.Undirected) bool {
+	a := g.AdjacencyList
+	var branch []graph.NI
+	isBranch := map[graph.NI]bool{}
+	for v, to := range a {
+		switch len(to) {
+		case 2:
+		case 3, 4:
+			branch = append(branch, graph.NI(v))
+			isBranch[graph.NI(v)] = true
+		default:
+			return false
+		}
+	}
+	deg := len(a[branch[0]])
+	for _, b := range branch {
+		if len(a[b]) != deg {
+			return false
+		}
+	}
+	// follow paths from branch nodes to other branch nodes
+	visited := make([]bool, len(a))
+	ends := map[[2]graph.NI]int{}
+	for _, b := range branch {
+		visited[b] = true
+		for _, w := range a[b] {
+			prev, v := b, w
+			for !isBranch[v] {
+				visited[v] = true
+				next := a[v][0]
+				if next == prev {
+					next = a[v][1]
+				}
+				prev, v = v, next
+			}
+			if v == b {
+				return false
+			}
+			ends[[2]graph.NI{b, v}]++
+		}
+	}
+	for _, vis := range visited {
+		if !vis {
+			return false // extra cycle
+		}
+	}
+	for _, c := range ends {
+		if c != 1 {
+			return false // parallel paths
+		}
+	}
+	switch {
+	case len(branch) == 5 && deg == 4:
+		return true // complete, as paths go to distinct branch nodes
+	case len(branch) == 6 && deg == 3:
+		// bipartite: color branch nodes by paths from branch[0]
+		side := map[graph.NI]int{branch[0]: 1}
+		for e := range ends {
+			if e[0] == branch[0] {
+				side[e[1]] = 2
+			}
+		}
+		for e := range ends {
+			if side[e[0]] != 0 && side[e[0]] == side[e[1]] {
+				return false
+			}
+		}
+		return len(side) == 4
+	}
+	return false
+}
+
+// TestPlanar checks rotation systems of planar graphs by Euler's formula and
+// checks Kuratowski subgraphs of non-planar graphs.
+func TestPlanar(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	nPlanar := 0
+	for i := 0; i < 300; i++ {
+		n := 1 + r.Intn(14)
+		m := r.Intn(2*n + 1)
+		if max := n * (n - 1) / 2; m > max {
+			m = max
+		}
+		g := graph.GnmUndirected(n, m, r)
+		if r.Intn(4) == 0 {
+			// a loop and a parallel edge
+			v := graph.NI(r.Intn(n))
+			g.AddEdge(v, v)
+			if len(g.AdjacencyList[v]) > 1 {
+				g.AddEdge(v, g.AdjacencyList[v][0])
+			}
+		}
+		planar, rot, k := g.Planar()
+		if !planar {
+			if !kuratowski(k.Undirected) {
+				t.Fatal("not a Kuratowski subgraph", k.Undirected)
+			}
+			continue
+		}
+		nPlanar++
+		// compare arcs
+		for v, to := range g.AdjacencyList {
+			c := map[graph.NI]int{}
+			for _, w := range to {
+				c[w]++
+			}
+			for _, w := range rot.AdjacencyList[v] {
+				c[w]--
+			}
+			for _, x := range c {
+				if x != 0 {
+					t.Fatal("rotation arcs differ from graph arcs")
+				}
+			}
+		}
+		// Euler's formula, per component, on the simple graph
+		s := make(graph.AdjacencyList, n)
+		edges := 0
+		for v, to := range rot.AdjacencyList {
+			for _, w := range to {
+				if w != graph.NI(v) &&
+					(len(s[v]) == 0 || s[v][len(s[v])-1] != w) {
+					s[v] = append(s[v], w)
+					edges++
+				}
+			}
+		}
+		edges /= 2
+		f := faces(s)
+		isolated := 0
+		for _, to := range s {
+			if len(to) == 0 {
+				isolated++
+			}
+		}
+		_, nc := graph.Undirected{s}.ConnectedComponentInts()
+		if n-edges+f+isolated != 2*nc {
+			t.Fatal("not a planar embedding", n, edges, f, isolated, nc)
+		}
+	}
+	if nPlanar == 0 || nPlanar == 300 {
+		t.Fatal(nPlanar, "planar graphs")
+	}
+}
@@ -7,7 +7,9 @@ package graph
 // LabeledUndirected.
 
 import (
+	"container/heap"
 	"fmt"
+	"math"
 
 	"github.com/soniakeys/bits"
 )
@@ -420,6 +422,457 @@ func (g Undirected) BlockCut(block func([]Edge) bool, cut func(NI) bool, isolate
 	}
 }
 
+// Bridges finds the bridges of a graph.
+//
+// A bridge is an edge whose removal would increase the number of connected
+// components of the graph.
+//
+// The method calls the emit argument for each bridge found, as long as emit
+// returns true.  If emit returns false, Bridges returns immediately.
+// The N2 node of each emitted edge is the node farther from the root of the
+// depth first search that found the bridge.
+//
+// Parallel edges are never bridges.  Loops are ignored.
+//
+// See also the equivalent labeled Bridges, and TwoEdgeConnectedComponents.
+func (g Undirected) Bridges(emit func(Edge) bool) {
+	a := g.AdjacencyList
+	number := make([]int, len(a))
+	lowpt := make([]int, len(a))
+	var i int
+	var df func(NI, NI) bool
+	df = func(v, u NI) bool {
+		i++
+		number[v] = i
+		lowpt[v] = i
+		skip := true // skip just one arc back to u, to allow parallel edges
+		for _, w := range a[v] {
+			if w == u && skip {
+				skip = false
+				continue
+			}
+			if number[w] == 0 {
+				if !df(w, v) {
+					return false
+				}
+				if lowpt[w] < lowpt[v] {
+					lowpt[v] = lowpt[w]
+				}
+				if lowpt[w] > number[v] && !emit(Edge{v, w}) {
+					return false
+				}
+			} else if number[w] < lowpt[v] {
+				lowpt[v] = number[w]
+			}
+		}
+		return true
+	}
+	for w := range a {
+		if number[w] == 0 && !df(NI(w), -1) {
+			return
+		}
+	}
+}
+
+// BlockCutTree constructs the block-cut tree of a graph.
+//
+// The block-cut tree has a node for each block, or maximal biconnected
+// component, and a node for each cut node of g.  It has an edge between a
+// block node and a cut node for each cut node in the block.  For a connected
+// graph the result is a tree.  For a disconnected graph it is a forest with
+// a tree for each connected component.
+//
+// Returned is the tree t and mappings between t and g.  The first len(blocks)
+// nodes of t are block nodes.  blocks[b] lists the nodes of g in the block
+// represented by node b of t.  Node len(blocks)+c of t is cut node cuts[c].
+// An isolated node of g, or a node with only loops, is a block by itself.
+// treeNode[n] is the node of t representing node n of g.  For cut nodes this
+// is the cut node of t, for other nodes it is the block node of the single
+// block containing the node.
+//
+// Blocks are in the order emitted by BlockCut, followed by isolated nodes.
+// Nodes within a block are in numerical order.
+func (g Undirected) BlockCutTree() (t Undirected, blocks [][]NI, cuts []NI, treeNode []NI) {
+	a := g.AdjacencyList
+	treeNode = make([]NI, len(a))
+	cutX := make([]int, len(a)) // index in cuts + 1, or 0 for non-cut nodes
+	inBlock := bits.New(len(a))
+	placed := bits.New(len(a)) // nodes in some block
+	g.BlockCut(func(bcc []Edge) bool {
+		inBlock.ClearAll()
+		for _, e := range bcc {
+			inBlock.SetBit(int(e.N1), 1)
+			inBlock.SetBit(int(e.N2), 1)
+		}
+		var b []NI
+		inBlock.IterateOnes(func(n int) bool {
+			b = append(b, NI(n))
+			return true
+		})
+		placed.Or(placed, inBlock)
+		blocks = append(blocks, b)
+		return true
+	}, func(n NI) bool {
+		if cutX[n] == 0 {
+			cuts = append(cuts, n)
+			cutX[n] = len(cuts)
+		}
+		return true
+	}, func(n NI) bool {
+		return true
+	})
+	// isolated nodes, including nodes with only loops, are blocks by
+	// themselves
+	placed.IterateZeros(func(n int) bool {
+		blocks = append(blocks, []NI{NI(n)})
+		return true
+	})
+	nb := len(blocks)
+	t.AdjacencyList = make(AdjacencyList, nb+len(cuts))
+	for c, n := range cuts {
+		treeNode[n] = NI(nb + c)
+	}
+	for b, nodes := range blocks {
+		for _, n := range nodes {
+			if x := cutX[n]; x > 0 {
+				t.AddEdge(NI(b), NI(nb+x-1))
+			} else {
+				treeNode[n] = NI(b)
+			}
+		}
+	}
+	return
+}
+
+// TrussNumbers computes the truss number of each edge of a graph.
+//
+// The k-truss of a graph is the maximal subgraph in which every edge is in
+// at least k-2 triangles of the subgraph.  The truss number of an edge is
+// the greatest k such that the edge is in the k-truss.  Every edge is in the
+// 2-truss, so the least truss number is 2.  Trusses nest and the k-truss is
+// contained in the (k-1)-core.
+//
+// Loops are ignored and parallel edges are treated as a single edge.
+// Returned is a map from each edge to its truss number.  Edges are keyed
+// with N1 < N2.
+//
+// The algorithm peels edges in order of triangle support, as by Wang and
+// Cheng.  Triangles containing an edge are found by intersecting neighbors
+// of the two end nodes.  Time complexity is O(EΔ) where Δ is the maximum
+// degree.
+//
+// See also KTruss and Degeneracy.
+func (g Undirected) TrussNumbers() map[Edge]int {
+	a := g.AdjacencyList
+	return trussNumbers(len(a), func(n NI, visit func(NI)) {
+		for _, to := range a[n] {
+			visit(to)
+		}
+	})
+}
+
+// KTruss constructs the k-truss of a graph as a subgraph.
+//
+// The k-truss is as described for TrussNumbers.  The subgraph has all edges
+// of g with truss number at least k, including parallel edges, and the
+// nodes of those edges.  Subgraph nodes are mapped in increasing order of
+// supergraph nodes.  Loops are not included.
+//
+// Receiver g becomes the supergraph of the subgraph.
+func (g *Undirected) KTruss(k int) *UndirectedSubgraph {
+	t := g.TrussNumbers()
+	s := &UndirectedSubgraph{Super: g, SubNI: map[NI]NI{}}
+	for e, te := range t {
+		if te < k {
+			delete(t, e)
+		}
+	}
+	for _, n := range trussNodes(t) {
+		s.AddNode(n)
+	}
+	for fr, to := range g.AdjacencyList {
+		for _, to := range to {
+			if _, ok := t[Edge{NI(fr), to}]; ok {
+				s.AddEdge(NI(fr), to)
+			}
+		}
+	}
+	return s
+}
+
+// trussNumbers implements TrussNumbers for a graph of order n.  Function
+// arcs must call visit for the neighbors of node n.
+func trussNumbers(n int, arcs func(n NI, visit func(NI))) map[Edge]int {
+	// simple graph with numbered edges.  nb has the neighbors of each node
+	// and id has the number of the edge to each neighbor, parallel to nb.
+	var ends []Edge
+	nb := make([][]NI, n)
+	id := make([][]int, n)
+	mark := make([]int, n) // stamped with node+1 for visited neighbors
+	for fr := range nb {
+		arcs(NI(fr), func(to NI) {
+			// edges are numbered from the lesser end node
+			if to <= NI(fr) || mark[to] == fr+1 {
+				return
+			}
+			mark[to] = fr + 1
+			x := len(ends)
+			ends = append(ends, Edge{NI(fr), to})
+			nb[fr] = append(nb[fr], to)
+			id[fr] = append(id[fr], x)
+			nb[to] = append(nb[to], NI(fr))
+			id[to] = append(id[to], x)
+		})
+	}
+	removed := make([]bool, len(ends))
+	seen := make([]int, n) // stamped for remaining neighbors of one end node
+	vid := make([]int, n)  // number of the edge to each stamped neighbor
+	stamp := 0
+	// third calls f with the edges u-w and v-w of remaining triangles
+	// containing edge x, u-v.
+	third := func(x int, f func(uw, vw int)) {
+		u, v := ends[x].N1, ends[x].N2
+		stamp++
+		for i, w := range nb[v] {
+			if vw := id[v][i]; !removed[vw] {
+				seen[w] = stamp
+				vid[w] = vw
+			}
+		}
+		for i, w := range nb[u] {
+			if uw := id[u][i]; seen[w] == stamp && !removed[uw] {
+				f(uw, vid[w])
+			}
+		}
+	}
+	// support is the number of triangles containing each edge.  bucket
+	// holds edges by support, with stale entries skipped when popped.
+	sup := make([]int, len(ends))
+	var bucket [][]int
+	for x := range ends {
+		third(x, func(int, int) { sup[x]++ })
+		for len(bucket) <= sup[x] {
+			bucket = append(bucket, nil)
+		}
+		bucket[sup[x]] = append(bucket[sup[x]], x)
+	}
+	truss := make([]int, len(ends))
+	dec := func(x, floor int) {
+		if sup[x] > floor {
+			sup[x]--
+			bucket[sup[x]] = append(bucket[sup[x]], x)
+		}
+	}
+	for k, left := 2, len(ends); left > 0; k++ {
+		b := k - 2
+		for len(bucket[b]) > 0 {
+			last := len(bucket[b]) - 1
+			x := bucket[b][last]
+			bucket[b] = bucket[b][:last]
+			if removed[x] || sup[x] != b {
+				continue
+			}
+			third(x, func(uw, vw int) {
+				dec(uw, b)
+				dec(vw, b)
+			})
+			removed[x] = true
+			truss[x] = k
+			left--
+		}
+	}
+	t := make(map[Edge]int, len(ends))
+	for x, e := range ends {
+		t[e] = truss[x]
+	}
+	return t
+}
+
+// trussNodes returns the end nodes of edges of t in increasing order.
+func trussNodes(t map[Edge]int) []NI {
+	var max NI = -1
+	for e := range t {
+		if e.N2 > max {
+			max = e.N2
+		}
+	}
+	b := bits.New(int(max) + 1)
+	for e := range t {
+		b.SetBit(int(e.N1), 1)
+		b.SetBit(int(e.N2), 1)
+	}
+	var nodes []NI
+	b.IterateOnes(func(n int) bool {
+		nodes = append(nodes, NI(n))
+		return true
+	})
+	return nodes
+}
+
+// triGraph is a simple graph oriented by degree for triangle enumeration.
+//
+// Each edge is oriented from the node of lower degree to the node of higher
+// degree, ties broken by node number.  Each node then has O(√E) out arcs.
+type triGraph struct {
+	deg []int       // number of distinct neighbors, not counting loops
+	out [][]triHalf // oriented arcs
+}
+
+// triHalf is an oriented arc of a triGraph.  Bit 1 of dir is set if the
+// original graph has an arc in the direction of the oriented arc, bit 2 if
+// it has an arc in the opposite direction.
+type triHalf struct {
+	to  NI
+	dir uint8
+}
+
+// newTriGraph constructs a triGraph for a graph of order n.  Function arcs
+// must call visit for the neighbors of node v.  Loops and parallel arcs are
+// ignored.
+func newTriGraph(n int, arcs func(v NI, visit func(to NI, dir uint8))) *triGraph {
+	nb := make([][]triHalf, n)
+	at := make([]int, n)   // index of neighbor in nb[v]
+	stamp := make([]NI, n) // v+1 when at is valid for v
+	for v := range nb {
+		fr := NI(v)
+		arcs(fr, func(to NI, dir uint8) {
+			switch {
+			case to == fr:
+			case stamp[to] == fr+1:
+				nb[v][at[to]].dir |= dir
+			default:
+				stamp[to] = fr + 1
+				at[to] = len(nb[v])
+				nb[v] = append(nb[v], triHalf{to, dir})
+			}
+		})
+	}
+	t := &triGraph{deg: make([]int, n), out: nb}
+	for v, h := range nb {
+		t.deg[v] = len(h)
+	}
+	for v, h := range nb {
+		o := h[:0]
+		for _, h := range h {
+			if d, dt := t.deg[v], t.deg[h.to]; d < dt || d == dt && NI(v) < h.to {
+				o = append(o, h)
+			}
+		}
+		t.out[v] = o
+	}
+	return t
+}
+
+// each calls f for each triangle u, v, w, where u, v, w is the orientation
+// order.  Also passed are dir bits for arcs u->v, u->w, and v->w.
+func (t *triGraph) each(f func(u, v, w NI, duv, duw, dvw uint8)) {
+	mark := make([]uint8, len(t.out))
+	for u, uo := range t.out {
+		for _, h := range uo {
+			mark[h.to] = h.dir
+		}
+		for _, h := range uo {
+			for _, h2 := range t.out[h.to] {
+				if d := mark[h2.to]; d != 0 {
+					f(NI(u), h.to, h2.to, h.dir, d, h2.dir)
+				}
+			}
+		}
+		for _, h := range uo {
+			mark[h.to] = 0
+		}
+	}
+}
+
+// minVertexCover implements MinimumVertexCover.  Argument nb has the
+// neighbors of each node, excluding loops.  Argument c has nodes that must
+// be in the cover and on return holds a minimum vertex cover.
+func minVertexCover(nb []bits.Bits, c bits.Bits) {
+	n := len(nb)
+	best := bits.New(n)
+	bestN := n + 1
+	t := bits.New(n)
+	deg := func(v int, alive bits.Bits) int {
+		t.And(nb[v], alive)
+		return t.OnesCount()
+	}
+	// search finds covers of the edges among nodes of alive.  nc is the
+	// number of nodes in cover.
+	var search func(alive, cover bits.Bits, nc int)
+	search = func(alive, cover bits.Bits, nc int) {
+		// drop isolated nodes, cover the neighbor of nodes of degree 1
+		for again := true; again; {
+			again = false
+			for v := alive.OneFrom(0); v >= 0; v = alive.OneFrom(v + 1) {
+				switch deg(v, alive) {
+				case 0:
+					alive.SetBit(v, 0)
+				case 1:
+					u := t.OneFrom(0)
+					cover.SetBit(u, 1)
+					nc++
+					alive.SetBit(u, 0)
+					alive.SetBit(v, 0)
+					again = true
+				}
+			}
+		}
+		if alive.OneFrom(0) < 0 {
+			if nc < bestN {
+				bestN = nc
+				best.Set(cover)
+			}
+			return
+		}
+		// the number of alive nodes less the number of cliques of a clique
+		// cover bounds the size of a vertex cover.
+		u := bits.New(n) // alive nodes not yet in a clique
+		u.Set(alive)
+		lb := 0
+		maxV, maxD := -1, 0
+		for v := alive.OneFrom(0); v >= 0; v = alive.OneFrom(v + 1) {
+			if d := deg(v, alive); d > maxD {
+				maxV, maxD = v, d
+			}
+			if u.Bit(v) == 1 {
+				u.SetBit(v, 0)
+				t.And(nb[v], u)
+				for w := t.OneFrom(0); w >= 0; w = t.OneFrom(w + 1) {
+					u.SetBit(w, 0)
+					t.And(t, nb[w])
+					lb++
+				}
+			}
+		}
+		if nc+lb >= bestN {
+			return
+		}
+		// branch: either maxV is in the cover or all its neighbors are.
+		a2 := bits.New(n)
+		a2.Set(alive)
+		a2.SetBit(maxV, 0)
+		c2 := bits.New(n)
+		c2.Set(cover)
+		c2.SetBit(maxV, 1)
+		search(a2, c2, nc+1)
+		// if all degrees are 2, what remains is cycles.  covering maxV
+		// leaves a path, solved optimally by the degree 1 rule.
+		if maxD <= 2 || nc+maxD >= bestN {
+			return
+		}
+		t.And(nb[maxV], alive)
+		alive.AndNot(alive, t)
+		alive.SetBit(maxV, 0)
+		cover.Or(cover, t)
+		search(alive, cover, nc+maxD)
+	}
+	alive := bits.New(n)
+	alive.SetAll()
+	alive.AndNot(alive, c)
+	search(alive, c, c.OnesCount())
+	c.Set(best)
+}
+
 // AddEdge adds an edge to a labeled graph.
 //
 // It can be useful for constructing undirected graphs.
@@ -756,6 +1209,268 @@ func (g LabeledUndirected) TarjanBiconnectedComponents(emit func([]LabeledEdge)
 	}
 }
 
+// Bridges finds the bridges of a graph.
+//
+// A bridge is an edge whose removal would increase the number of connected
+// components of the graph.
+//
+// The method calls the emit argument for each bridge found, as long as emit
+// returns true.  If emit returns false, Bridges returns immediately.
+// Emitted edges carry the label of the bridge.  The N2 node of each emitted
+// edge is the node farther from the root of the depth first search that
+// found the bridge.
+//
+// Parallel edges are never bridges.  Loops are ignored.
+//
+// See also the equivalent unlabeled Bridges, and TwoEdgeConnectedComponents.
+func (g LabeledUndirected) Bridges(emit func(LabeledEdge) bool) {
+	// Code nearly identical to unlabled version.
+	a := g.LabeledAdjacencyList
+	number := make([]int, len(a))
+	lowpt := make([]int, len(a))
+	var i int
+	var df func(NI, NI) bool
+	df = func(v, u NI) bool {
+		i++
+		number[v] = i
+		lowpt[v] = i
+		skip := true
+		for _, w := range a[v] {
+			if w.To == u && skip {
+				skip = false
+				continue
+			}
+			if number[w.To] == 0 {
+				if !df(w.To, v) {
+					return false
+				}
+				if lowpt[w.To] < lowpt[v] {
+					lowpt[v] = lowpt[w.To]
+				}
+				if lowpt[w.To] > number[v] &&
+					!emit(LabeledEdge{Edge{v, w.To}, w.Label}) {
+					return false
+				}
+			} else if number[w.To] < lowpt[v] {
+				lowpt[v] = number[w.To]
+			}
+		}
+		return true
+	}
+	for w := range a {
+		if number[w] == 0 && !df(NI(w), -1) {
+			return
+		}
+	}
+}
+
+// TrussNumbers computes the truss number of each edge of a labeled graph.
+//
+// This is as described for Undirected.TrussNumbers except that returned map
+// keys are labeled edges.  Triangles are those of the unlabeled graph.
+// Parallel edges with different labels have distinct keys and the same
+// truss number.
+func (g LabeledUndirected) TrussNumbers() map[LabeledEdge]int {
+	a := g.LabeledAdjacencyList
+	t := trussNumbers(len(a), func(n NI, visit func(NI)) {
+		for _, to := range a[n] {
+			visit(to.To)
+		}
+	})
+	lt := make(map[LabeledEdge]int, len(t))
+	for fr, to := range a {
+		for _, to := range to {
+			e := Edge{NI(fr), to.To}
+			if x, ok := t[e]; ok {
+				lt[LabeledEdge{e, to.Label}] = x
+			}
+		}
+	}
+	return lt
+}
+
+// KTruss constructs the k-truss of a labeled graph as a subgraph.
+//
+// This is as described for Undirected.KTruss.  Edge labels are preserved.
+//
+// Receiver g becomes the supergraph of the subgraph.
+func (g *LabeledUndirected) KTruss(k int) *LabeledUndirectedSubgraph {
+	a := g.LabeledAdjacencyList
+	t := trussNumbers(len(a), func(n NI, visit func(NI)) {
+		for _, to := range a[n] {
+			visit(to.To)
+		}
+	})
+	s := &LabeledUndirectedSubgraph{Super: g, SubNI: map[NI]NI{}}
+	for e, te := range t {
+		if te < k {
+			delete(t, e)
+		}
+	}
+	for _, n := range trussNodes(t) {
+		s.AddNode(n)
+	}
+	for fr, to := range a {
+		for _, to := range to {
+			e := Edge{NI(fr), to.To}
+			if _, ok := t[e]; ok {
+				s.AddEdge(e, to.Label)
+			}
+		}
+	}
+	return s
+}
+
+// MinCostMatching finds a minimum cost maximum cardinality matching of a
+// weighted bipartite graph.
+//
+// This solves the "assignment problem" where nodes of one color represent
+// for example workers and nodes of the other color represent tasks.  Edge
+// weights, the costs of assignments, are given by WeightFunc w.  Negative
+// weights are allowed.  To find a maximum weight matching instead, supply a
+// WeightFunc returning negated weights.
+//
+// The partitions need not be of the same size and edges may be missing.
+// Of all matchings with the maximum possible number of edges, a matching with
+// minimum total cost is found.  Returned slice match has an element for each
+// node of g.  For matched nodes n, match[n] is a half arc leading to the mate
+// of n, with the label of the matched edge.  For unmatched nodes, match[n].To
+// is -1.  Return value cost is the total weight of matched edges.  Return
+// value ok is true if the matching is complete, meaning that all nodes of the
+// smaller color are matched.  A false value indicates that no complete
+// assignment is possible.
+//
+// The algorithm is the Hungarian method in the form of successive shortest
+// augmenting paths with node potentials.  Each augmentation is a Dijkstra
+// search of the current residual graph so time complexity is
+// O(V E log V).
+//
+// Parallel edges are allowed.  Loops are not allowed in a bipartite graph.
+func (g LabeledBipartite) MinCostMatching(w WeightFunc) (match []Half, cost float64, ok bool) {
+	a := g.LabeledAdjacencyList
+	n := len(a)
+	match = make([]Half, n)
+	for i := range match {
+		match[i] = Half{-1, -1}
+	}
+	// mx is the index in a[u] of the matched arc, for matched color 0 nodes
+	mx := make([]int, n)
+	// Residual graph has virtual source s leading to free color 0 nodes and
+	// virtual sink t reached from free color 1 nodes.
+	s, t := n, n+1
+	inf := math.Inf(1)
+	pot := make([]float64, n+2)
+	for i := range pot[:n] {
+		pot[i] = inf
+	}
+	g.Color.IterateZeros(func(u int) bool {
+		pot[u] = 0
+		for _, h := range a[u] {
+			if wt := w(h.Label); wt < pot[h.To] {
+				pot[h.To] = wt
+			}
+		}
+		return true
+	})
+	pot[s] = 0
+	pot[t] = inf
+	for v, p := range pot[:n] {
+		if p == inf {
+			pot[v] = 0 // isolated color 1 node
+		}
+		if pot[v] < pot[t] {
+			pot[t] = pot[v]
+		}
+	}
+	if pot[t] == inf {
+		pot[t] = 0
+	}
+	r := make([]tentResult, n+2)
+	pred := make([]NI, n+2) // color 0 node leading to color 1 node or to t
+	predX := make([]int, n) // arc index in a[pred[v]], for color 1 nodes v
+	var h tent
+	for {
+		for i := range r {
+			r[i] = tentResult{dist: inf, nx: NI(i)}
+		}
+		relax := func(to NI, d float64) bool {
+			if r[to].done || d >= r[to].dist {
+				return false
+			}
+			if r[to].dist == inf {
+				r[to].dist = d
+				heap.Push(&h, &r[to])
+			} else {
+				r[to].dist = d
+				heap.Fix(&h, r[to].fx)
+			}
+			return true
+		}
+		g.Color.IterateZeros(func(u int) bool {
+			if match[u].To < 0 {
+				relax(NI(u), pot[s]-pot[u])
+			}
+			return true
+		})
+		for len(h) > 0 {
+			c := heap.Pop(&h).(*tentResult)
+			c.done = true
+			switch u := c.nx; {
+			case int(u) == t:
+			case g.Color.Bit(int(u)) == 0:
+				for x, to := range a[u] {
+					if x == mx[u] && match[u].To >= 0 {
+						continue
+					}
+					d := c.dist + w(to.Label) + pot[u] - pot[to.To]
+					if relax(to.To, d) {
+						pred[to.To] = u
+						predX[to.To] = x
+					}
+				}
+			case match[u].To < 0:
+				if relax(NI(t), c.dist+pot[u]-pot[t]) {
+					pred[t] = u
+				}
+			default:
+				m := match[u]
+				relax(m.To, c.dist-w(m.Label)+pot[u]-pot[m.To])
+			}
+		}
+		if !r[t].done {
+			break // no augmenting path
+		}
+		for i := range r {
+			if r[i].done {
+				pot[i] += r[i].dist
+			}
+		}
+		// augment along path
+		for v := pred[t]; v >= 0; {
+			u := pred[v]
+			prev := match[u].To
+			l := a[u][predX[v]].Label
+			match[u] = Half{v, l}
+			match[v] = Half{u, l}
+			mx[u] = predX[v]
+			v = prev
+		}
+	}
+	nm := 0
+	g.Color.IterateZeros(func(u int) bool {
+		if m := match[u]; m.To >= 0 {
+			cost += w(m.Label)
+			nm++
+		}
+		return true
+	})
+	small := g.N0
+	if n-g.N0 < small {
+		small = n - g.N0
+	}
+	return match, cost, nm == small
+}
+
 func (e *eulerian) pushUndir() error {
 	for u := e.top(); ; {
 		e.uv.SetBit(int(u), 0)
@@ -17,6 +17,59 @@ import (
 // in case you start to edit the file.
 //-------------------
 
+// ApproxVertexCover finds a vertex cover of a graph within a factor of two
+// of minimum.
+//
+// A vertex cover is a set of nodes such that every edge has at least one end
+// in the set.  The cover found is the set of end nodes of a greedy maximal
+// matching.  A minimum vertex cover must contain an end of each matched edge
+// so the cover found is at most twice the size of a minimum vertex cover.
+// A node with a loop is always in the cover.
+//
+// Time complexity is O(V + E).
+//
+// See also MinimumVertexCover.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) ApproxVertexCover() bits.Bits {
+	a := g.AdjacencyList
+	c := bits.New(len(a))
+	for fr, to := range a {
+		if c.Bit(fr) == 1 {
+			continue
+		}
+		for _, to := range to {
+			if c.Bit(int(to)) == 0 {
+				c.SetBit(fr, 1)
+				c.SetBit(int(to), 1)
+				break
+			}
+		}
+	}
+	return c
+}
+
+// AverageClustering returns the average of local clustering coefficients
+// of the nodes of a graph.
+//
+// Nodes with fewer than two neighbors have coefficient 0 and are included in
+// the average.  A graph with no nodes has average clustering 0.
+//
+// See also LocalClustering and Transitivity.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) AverageClustering() float64 {
+	c := g.LocalClustering()
+	if len(c) == 0 {
+		return 0
+	}
+	var s float64
+	for _, c := range c {
+		s += c
+	}
+	return s / float64(len(c))
+}
+
 // Bipartite constructs an object indexing the bipartite structure of a graph.
 //
 // In a bipartite component, nodes can be partitioned into two sets, or
@@ -559,6 +612,88 @@ func (g Undirected) Copy() (c Undirected, ma int) {
 	return Undirected{l}, s
 }
 
+// DSatur colors the nodes of a graph by the DSatur heuristic of Brélaz.
+//
+// Nodes are colored one at a time, at each step choosing an uncolored node
+// adjacent to the most distinct colors, breaking ties by choosing a node of
+// maximum degree among uncolored nodes.  The node is given the smallest
+// color not used by its neighbors.
+//
+// Returned is a color for each node, numbered from 0, and the number of
+// colors used.  Loops are ignored.
+//
+// DSatur finds an optimal coloring for bipartite graphs.  It often does
+// better than GreedyColoring on other graphs but is somewhat slower.
+// Time complexity is O(V² + EΔ) where Δ is the maximum degree.
+//
+// See also GreedyColoring and ExactColoring.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) DSatur() (color []int, nColors int) {
+	a := g.AdjacencyList
+	color = make([]int, len(a))
+	sat := make([]int, len(a))  // number of distinct neighbor colors
+	deg := make([]int, len(a))  // number of distinct uncolored neighbors
+	seen := make([]int, len(a)) // stamped for counted neighbors
+	stamp := 0
+	for n, to := range a {
+		color[n] = -1
+		stamp++
+		seen[n] = stamp
+		for _, to := range to {
+			if seen[to] != stamp {
+				seen[to] = stamp
+				deg[n]++
+			}
+		}
+	}
+	mark := make([]int, len(a)+1) // stamped with node+1 for marked colors
+	for range a {
+		v := -1
+		for n, c := range color {
+			if c < 0 && (v < 0 || sat[n] > sat[v] ||
+				sat[n] == sat[v] && deg[n] > deg[v]) {
+				v = n
+			}
+		}
+		for _, to := range a[v] {
+			if c := color[to]; c >= 0 {
+				mark[c] = v + 1
+			}
+		}
+		c := 0
+		for mark[c] == v+1 {
+			c++
+		}
+		color[v] = c
+		if c == nColors {
+			nColors++
+		}
+		// update distinct uncolored neighbors
+		stamp++
+		for _, to := range a[v] {
+			u := to
+			if color[u] >= 0 || seen[u] == stamp {
+				continue
+			}
+			seen[u] = stamp
+			deg[u]--
+			// u gains saturation if no other neighbor has color c
+			newColor := true
+			for _, w := range a[u] {
+				if w != NI(v) && color[w] == c {
+					newColor = false
+					break
+				}
+			}
+			if newColor {
+				sat[u]++
+			}
+		}
+	}
+	return
+}
+
 // Degeneracy is a measure of dense subgraphs within a graph.
 //
 // See Wikipedia https://en.wikipedia.org/wiki/Degeneracy_(graph_theory)
@@ -948,6 +1083,308 @@ func (g Undirected) EulerianStart() NI {
 	return -1
 }
 
+// ExactColoring colors the nodes of a graph with the minimum number of
+// colors.
+//
+// The minimum number of colors is the chromatic number of the graph.
+// The algorithm is a branch and bound search, choosing nodes to color in
+// the order of DSatur.  An initial upper bound is the number of colors
+// found by DSatur and a lower bound is the size of a clique found greedily.
+// Time complexity is exponential in the worst case.  The method is practical
+// for small graphs, or larger graphs that are sparse or have a large clique.
+//
+// Returned is a color for each node, numbered from 0, and the number of
+// colors used.  Loops are ignored.
+//
+// See also DSatur and GreedyColoring.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) ExactColoring() (color []int, nColors int) {
+	a := g.AdjacencyList
+	color, nColors = g.DSatur()
+	if nColors <= 2 {
+		return // DSatur is exact for bipartite graphs
+	}
+	// lower bound from a greedy clique
+	lb := 0
+	cand := bits.New(len(a))
+	cand.SetAll()
+	nb := bits.New(len(a))
+	for !cand.AllZeros() {
+		v, vd := -1, -1
+		cand.IterateOnes(func(n int) bool {
+			if len(a[n]) > vd {
+				v, vd = n, len(a[n])
+			}
+			return true
+		})
+		lb++
+		nb.ClearAll()
+		for _, to := range a[v] {
+			nb.SetBit(int(to), 1)
+		}
+		nb.SetBit(v, 0)
+		cand.And(cand, nb)
+	}
+	if nColors == lb {
+		return
+	}
+	c := make([]int, len(a))
+	for n := range c {
+		c[n] = -1
+	}
+	mark := make([]int, len(a)) // stamped for marked colors
+	stamp := 0
+	// search colors nodes given k colors used and nodes done already
+	// colored.  It returns true when an optimal coloring is found.
+	var search func(k, done int) bool
+	search = func(k, done int) bool {
+		if done == len(a) {
+			copy(color, c)
+			nColors = k
+			return k == lb
+		}
+		// select uncolored node of max saturation, then max degree
+		v, vSat := -1, -1
+		for n := range a {
+			if c[n] >= 0 {
+				continue
+			}
+			stamp++
+			sat := 0
+			for _, to := range a[n] {
+				if x := c[to]; x >= 0 && mark[x] != stamp {
+					mark[x] = stamp
+					sat++
+				}
+			}
+			if sat > vSat || sat == vSat && len(a[n]) > len(a[v]) {
+				v, vSat = n, sat
+			}
+		}
+		stamp++
+		for _, to := range a[v] {
+			if x := c[to]; x >= 0 {
+				mark[x] = stamp
+			}
+		}
+		cs := stamp // search may change stamp
+		for x := 0; x <= k && x < nColors-1; x++ {
+			if mark[x] == cs {
+				continue
+			}
+			c[v] = x
+			nk := k
+			if x == k {
+				nk++
+			}
+			if search(nk, done+1) {
+				return true
+			}
+			// mark may have been overwritten.  recompute.
+			stamp++
+			cs = stamp
+			for _, to := range a[v] {
+				if y := c[to]; y >= 0 && to != NI(v) {
+					mark[y] = cs
+				}
+			}
+		}
+		c[v] = -1
+		return false
+	}
+	search(0, 0)
+	return
+}
+
+// GreedyColoring colors the nodes of a graph in a given order.
+//
+// Nodes are colored in the order of argument order, each with the smallest
+// color not used by its already colored neighbors.  Order must contain each
+// node of g exactly once.  If order is nil, the order of DegeneracyOrdering
+// is used.  This "smallest last" order uses at most d+1 colors where d is
+// the degeneracy of the graph.
+//
+// Returned is a color for each node, numbered from 0, and the number of
+// colors used.  Loops are ignored.
+//
+// Time complexity is O(V + E).
+//
+// See also DSatur and ExactColoring.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) GreedyColoring(order []NI) (color []int, nColors int) {
+	a := g.AdjacencyList
+	if order == nil {
+		order, _ = g.DegeneracyOrdering()
+	}
+	color = make([]int, len(a))
+	for n := range color {
+		color[n] = -1
+	}
+	mark := make([]int, len(a)+1) // stamped with node+1 for marked colors
+	for _, v := range order {
+		for _, to := range a[v] {
+			if c := color[to]; c >= 0 {
+				mark[c] = int(v) + 1
+			}
+		}
+		c := 0
+		for mark[c] == int(v)+1 {
+			c++
+		}
+		color[v] = c
+		if c == nColors {
+			nColors++
+		}
+	}
+	return
+}
+
+// GreedyDominatingSet finds a dominating set of a graph.
+//
+// A dominating set is a set of nodes such that every node of the graph is
+// either in the set or adjacent to a node in the set.  Nodes are chosen
+// greedily, each time a node that dominates the greatest number of nodes not
+// yet dominated.  The size of the set found is within a factor of 1 + ln V
+// of the size of a minimum dominating set.
+//
+// Loops and parallel edges are ignored.
+//
+// Time complexity is O(V + E) times the maximum degree in the worst case
+// but typically much less.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) GreedyDominatingSet() bits.Bits {
+	a := g.AdjacencyList
+	s := bits.New(len(a))
+	dom := bits.New(len(a))
+	mark := make([]int, len(a)) // stamped for counted nodes
+	stamp := 0
+	// gain returns the number of undominated nodes that v would dominate.
+	gain := func(v int) (c int) {
+		stamp++
+		mark[v] = stamp
+		c = 1 - dom.Bit(v)
+		for _, to := range a[v] {
+			if u := int(to); mark[u] != stamp {
+				mark[u] = stamp
+				c += 1 - dom.Bit(u)
+			}
+		}
+		return
+	}
+	// nodes by gain.  gains only decrease so entries are updated lazily.
+	var bucket [][]int
+	push := func(v, gv int) {
+		for len(bucket) <= gv {
+			bucket = append(bucket, nil)
+		}
+		bucket[gv] = append(bucket[gv], v)
+	}
+	for v := len(a) - 1; v >= 0; v-- {
+		push(v, gain(v))
+	}
+	for d := len(bucket) - 1; d > 0; {
+		b := bucket[d]
+		if len(b) == 0 {
+			d--
+			continue
+		}
+		v := b[len(b)-1]
+		bucket[d] = b[:len(b)-1]
+		if gv := gain(v); gv < d {
+			push(v, gv)
+			continue
+		}
+		s.SetBit(v, 1)
+		dom.SetBit(v, 1)
+		for _, to := range a[v] {
+			dom.SetBit(int(to), 1)
+		}
+	}
+	return s
+}
+
+// GreedyIndependentSet finds a maximal independent set of a graph.
+//
+// An independent set is a set of nodes no two of which are adjacent.  Nodes
+// are chosen greedily, each time a node of minimum degree in the graph that
+// remains after removing chosen nodes and their neighbors.  The set found is
+// maximal, in that no node can be added to it, but not generally maximum.
+//
+// Nodes with loops are not included.  Degrees count parallel edges.
+//
+// Time complexity is O(V + E).
+//
+// See also MaximumIndependentSet.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) GreedyIndependentSet() bits.Bits {
+	a := g.AdjacencyList
+	s := bits.New(len(a))
+	gone := bits.New(len(a)) // chosen, adjacent to chosen, or with a loop
+	for v, to := range a {
+		for _, to := range to {
+			if to == NI(v) {
+				gone.SetBit(v, 1)
+			}
+		}
+	}
+	deg := make([]int, len(a))
+	for v, to := range a {
+		for _, to := range to {
+			if gone.Bit(int(to)) == 0 {
+				deg[v]++
+			}
+		}
+	}
+	// nodes by degree.  degrees only decrease so entries are updated lazily.
+	var bucket [][]NI
+	push := func(v NI) {
+		for len(bucket) <= deg[v] {
+			bucket = append(bucket, nil)
+		}
+		bucket[deg[v]] = append(bucket[deg[v]], v)
+	}
+	for v := len(a) - 1; v >= 0; v-- {
+		if gone.Bit(v) == 0 {
+			push(NI(v))
+		}
+	}
+	for d := 0; d < len(bucket); {
+		b := bucket[d]
+		if len(b) == 0 {
+			d++
+			continue
+		}
+		v := b[len(b)-1]
+		bucket[d] = b[:len(b)-1]
+		if gone.Bit(int(v)) == 1 || deg[v] != d {
+			continue
+		}
+		s.SetBit(int(v), 1)
+		gone.SetBit(int(v), 1)
+		for _, to := range a[v] {
+			u := to
+			if gone.Bit(int(u)) == 1 {
+				continue
+			}
+			gone.SetBit(int(u), 1)
+			for _, to := range a[u] {
+				if w := to; gone.Bit(int(w)) == 0 {
+					deg[w]--
+					push(w)
+					if deg[w] < d {
+						d = deg[w]
+					}
+				}
+			}
+		}
+	}
+	return s
+}
+
 // AddNode maps a node in a supergraph to a subgraph node.
 //
 // Argument p must be an NI in supergraph s.Super.  AddNode panics if
@@ -1072,6 +1509,243 @@ func (g Undirected) IsTree(root NI) (isTree, allTree bool) {
 	return true, v.AllZeros()
 }
 
+// LocalClustering returns the local clustering coefficient of each node of
+// a graph.
+//
+// The local clustering coefficient of a node is the fraction of pairs of its
+// neighbors that are adjacent, that is, the number of triangles containing
+// the node divided by d(d-1)/2 where d is the number of neighbors.  Nodes
+// with fewer than two neighbors have coefficient 0.  Loops and parallel
+// edges are ignored.
+//
+// Time complexity is that of Triangles.
+//
+// See also AverageClustering and Transitivity.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) LocalClustering() []float64 {
+	t, _, deg := g.triangles()
+	c := make([]float64, len(t))
+	for n, d := range deg {
+		if d > 1 {
+			c[n] = 2 * float64(t[n]) / float64(d*(d-1))
+		}
+	}
+	return c
+}
+
+// MaximumClique finds a maximum clique of a graph.
+//
+// A maximum clique is a clique of the greatest number of nodes.  Its size is
+// the clique number of the graph.
+//
+// The algorithm is branch and bound in the style of Tomita's MCQ.  Nodes
+// are searched in order of decreasing degree.  At each step, candidate nodes
+// are greedily colored and the number of colors bounds the size of a clique
+// that can be found among them.  Time complexity is exponential in the worst
+// case, but the method is practical for graphs of hundreds of nodes, even
+// dense ones.
+//
+// Returned is the set of nodes of a maximum clique.  Loops and parallel
+// edges are ignored.
+//
+// See also MaximumWeightClique, and BronKerbosch3 which finds all maximal
+// cliques.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) MaximumClique() bits.Bits {
+	c, _ := g.maxClique(nil)
+	return c
+}
+
+// MaximumIndependentSet finds a maximum independent set of a graph.
+//
+// An independent set is a set of nodes no two of which are adjacent.  A
+// maximum independent set is one of the greatest number of nodes.  It is the
+// complement of a minimum vertex cover and is found as such.  Time
+// complexity is that of MinimumVertexCover, exponential in the worst case,
+// so the method is practical only for small graphs or graphs with small
+// vertex covers.  For larger graphs, GreedyIndependentSet finds a maximal
+// independent set in linear time.
+//
+// Nodes with loops are not included.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) MaximumIndependentSet() bits.Bits {
+	c := g.MinimumVertexCover()
+	s := bits.New(len(g.AdjacencyList))
+	s.SetAll()
+	s.AndNot(s, c)
+	return s
+}
+
+// MaximumWeightClique finds a maximum weight clique of a graph.
+//
+// Argument w is a weight for each node of g.  Weights must be non-negative.
+// A maximum weight clique is a clique with the greatest sum of node weights.
+//
+// The algorithm is that of MaximumClique except that the bound for a
+// coloring is the sum over color classes of the greatest weight in each
+// class.
+//
+// Returned is the set of nodes of a maximum weight clique and its weight.
+// Nodes of weight 0 may be omitted from the clique.  Loops and parallel
+// edges are ignored.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) MaximumWeightClique(w []float64) (c bits.Bits, weight float64) {
+	return g.maxClique(w)
+}
+
+// maxClique implements MaximumClique and MaximumWeightClique.  Weights w
+// are 1 if w is nil.
+func (g Undirected) maxClique(w []float64) (bits.Bits, float64) {
+	a := g.AdjacencyList
+	n := len(a)
+	if n == 0 {
+		return bits.New(0), 0
+	}
+	// nodes are renumbered as search nodes in order of decreasing degree,
+	// by a counting sort, so that bit order is search order.
+	deg := make([]int, n)  // number of distinct neighbors
+	mark := make([]int, n) // stamped with node+1 for counted neighbors
+	start := make([]int, n+1)
+	for v, to := range a {
+		mark[v] = v + 1
+		for _, to := range to {
+			if mark[to] != v+1 {
+				mark[to] = v + 1
+				deg[v]++
+			}
+		}
+		start[n-deg[v]]++
+	}
+	for d := 1; d <= n; d++ {
+		start[d] += start[d-1]
+	}
+	order := make([]int, n) // graph node of each search node
+	pos := make([]int, n)   // search node of each graph node
+	for v, d := range deg {
+		x := start[n-d-1]
+		start[n-d-1]++
+		order[x] = v
+		pos[v] = x
+	}
+	nb := make([]bits.Bits, n)
+	wt := make([]float64, n)
+	for x, v := range order {
+		nb[x] = bits.New(n)
+		for _, to := range a[v] {
+			if to != NI(v) {
+				nb[x].SetBit(pos[to], 1)
+			}
+		}
+		wt[x] = 1
+		if w != nil {
+			wt[x] = w[v]
+		}
+	}
+	// color greedily colors the nodes of p.  It returns the nodes in order
+	// of color and for each an upper bound on the weight of a clique of that
+	// node and nodes preceding it.
+	color := func(p bits.Bits) (vs []int, ub []float64) {
+		u := bits.New(n)
+		u.Set(p)
+		q := bits.New(n)
+		var bound float64
+		for !u.AllZeros() {
+			q.Set(u)
+			var max float64
+			c0 := len(vs)
+			for v := q.OneFrom(0); v >= 0; v = q.OneFrom(v + 1) {
+				vs = append(vs, v)
+				u.SetBit(v, 0)
+				q.AndNot(q, nb[v])
+				if wt[v] > max {
+					max = wt[v]
+				}
+			}
+			bound += max
+			for range vs[c0:] {
+				ub = append(ub, bound)
+			}
+		}
+		return
+	}
+	var c, best []int
+	var bestW float64
+	var expand func(cw float64, p bits.Bits)
+	expand = func(cw float64, p bits.Bits) {
+		vs, ub := color(p)
+		for i := len(vs) - 1; i >= 0; i-- {
+			if cw+ub[i] <= bestW {
+				return
+			}
+			v := vs[i]
+			c = append(c, v)
+			cw2 := cw + wt[v]
+			if cw2 > bestW {
+				bestW = cw2
+				best = append(best[:0], c...)
+			}
+			p2 := bits.New(n)
+			p2.And(p, nb[v])
+			if !p2.AllZeros() {
+				expand(cw2, p2)
+			}
+			c = c[:len(c)-1]
+			p.SetBit(v, 0)
+		}
+	}
+	p := bits.New(n)
+	p.SetAll()
+	expand(0, p)
+	clique := bits.New(n)
+	for _, x := range best {
+		clique.SetBit(order[x], 1)
+	}
+	return clique, bestW
+}
+
+// MinimumVertexCover finds a minimum vertex cover of a graph.
+//
+// A vertex cover is a set of nodes such that every edge has at least one end
+// in the set.  A minimum vertex cover is one of the fewest nodes.
+//
+// The algorithm is branch and bound.  Nodes of degree 0 are dropped and the
+// neighbors of nodes of degree 1 are taken into the cover.  Otherwise the
+// search branches on a node v of maximum degree, taking either v or all
+// neighbors of v into the cover.  Searches are bounded using a greedy clique
+// cover, as a vertex cover must contain all but one node of each clique.
+// Graphs of maximum degree 2 are solved without branching.  Time complexity
+// is exponential in the worst case.  The method is practical for graphs of
+// a hundred or so nodes, and for larger sparse graphs where most nodes are
+// removed by the degree rules.
+//
+// A node with a loop is always in the cover.  Parallel edges are ignored.
+//
+// See also ApproxVertexCover, MaximumIndependentSet, and, for bipartite
+// graphs, KonigCover.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) MinimumVertexCover() bits.Bits {
+	a := g.AdjacencyList
+	nb := make([]bits.Bits, len(a))
+	c := bits.New(len(a))
+	for v, to := range a {
+		nb[v] = bits.New(len(a))
+		for _, to := range to {
+			if to == NI(v) {
+				c.SetBit(v, 1)
+			} else {
+				nb[v].SetBit(int(to), 1)
+			}
+		}
+	}
+	minVertexCover(nb, c)
+	return c
+}
+
 // Size returns the number of edges in g.
 //
 // See also ArcSize and AnyLoop.
@@ -1088,6 +1762,144 @@ func (g Undirected) Size() int {
 	return m2 / 2
 }
 
+// Transitivity returns the global clustering coefficient of a graph.
+//
+// Transitivity is the fraction of connected triples of nodes that are
+// triangles, computed as 3 times the number of triangles divided by the
+// number of paths of length 2.  A graph with no paths of length 2 has
+// transitivity 0.  Loops and parallel edges are ignored.
+//
+// Time complexity is that of Triangles.
+//
+// See also AverageClustering and LocalClustering.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) Transitivity() float64 {
+	_, total, deg := g.triangles()
+	triples := 0
+	for _, d := range deg {
+		triples += d * (d - 1) / 2
+	}
+	if triples == 0 {
+		return 0
+	}
+	return 3 * float64(total) / float64(triples)
+}
+
+// Triangles counts triangles of a graph.
+//
+// Returned perNode is the number of triangles containing each node.
+// Returned total is the number of triangles of the graph.  Loops and
+// parallel edges are ignored.
+//
+// Edges are oriented from lower to higher degree nodes and triangles
+// are found from oriented arcs.  Time complexity is O(E^1.5).
+//
+// See also LocalClustering and Transitivity.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) Triangles() (perNode []int, total int) {
+	perNode, total, _ = g.triangles()
+	return
+}
+
+// triangles implements Triangles, also returning the number of distinct
+// neighbors of each node.
+func (g Undirected) triangles() (perNode []int, total int, deg []int) {
+	a := g.AdjacencyList
+	t := newTriGraph(len(a), func(n NI, visit func(NI, uint8)) {
+		for _, to := range a[n] {
+			visit(to, 3)
+		}
+	})
+	perNode = make([]int, len(a))
+	t.each(func(u, v, w NI, _, _, _ uint8) {
+		perNode[u]++
+		perNode[v]++
+		perNode[w]++
+		total++
+	})
+	return perNode, total, t.deg
+}
+
+// TwoEdgeConnectedComponents identifies the 2-edge-connected components of
+// a graph.
+//
+// A 2-edge-connected component is a maximal set of nodes that remain
+// connected if any single edge is removed.  The components are the
+// connected components that remain after removing all bridges.
+//
+// The method assigns numbers to components 1-based, 1 through the number of
+// components, in order of the lowest numbered node of each component.
+// Return value ci contains the component number for each node.  Return value
+// nc is the number of components.  An isolated node is a component by itself.
+//
+// Parallel edges are allowed.  Loops are ignored.
+//
+// See also Bridges.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Undirected) TwoEdgeConnectedComponents() (ci []int, nc int) {
+	a := g.AdjacencyList
+	number := make([]int, len(a))
+	lowpt := make([]int, len(a))
+	ci = make([]int, len(a))
+	var stack []NI
+	var i int
+	var df func(NI, NI)
+	df = func(v, u NI) {
+		i++
+		number[v] = i
+		lowpt[v] = i
+		stack = append(stack, v)
+		skip := true // skip just one arc back to u, to allow parallel edges
+		for _, w := range a[v] {
+			if w == u && skip {
+				skip = false
+				continue
+			}
+			if number[w] == 0 {
+				df(w, v)
+				if lowpt[w] < lowpt[v] {
+					lowpt[v] = lowpt[w]
+				}
+			} else if number[w] < lowpt[v] {
+				lowpt[v] = number[w]
+			}
+		}
+		if lowpt[v] == number[v] {
+			// the edge u-v is a bridge or v is a root.  v and the nodes
+			// above it on the stack are a component.
+			nc++
+			for {
+				top := len(stack) - 1
+				n := stack[top]
+				stack = stack[:top]
+				ci[n] = nc
+				if n == v {
+					break
+				}
+			}
+		}
+	}
+	for n := range a {
+		if number[n] == 0 {
+			df(NI(n), -1)
+		}
+	}
+	// renumber in order of lowest node
+	m := make([]int, nc+1)
+	c := 0
+	for n, x := range ci {
+		if m[x] == 0 {
+			c++
+			m[x] = c
+		}
+		ci[n] = m[x]
+	}
+	return
+}
+
 // Density returns edge density of a bipartite graph.
 //
 // Edge density is number of edges over maximum possible number of edges.
@@ -1103,6 +1915,161 @@ func (g Bipartite) Density() float64 {
 	return float64(s) / float64(g.N0*(len(a)-g.N0))
 }
 
+// HopcroftKarp finds a maximum cardinality matching of a bipartite graph.
+//
+// The algorithm is that of Hopcroft and Karp and runs in O(E√V) time.
+//
+// Returned is a mate slice of length g.Order().  For each matched node n,
+// mate[n] is the node it is matched with.  For unmatched nodes, mate[n] is
+// -1.  Also returned is the size of the matching, the number of matched
+// edges.
+//
+// Parallel edges are allowed but are redundant.  Loops are not allowed in a
+// bipartite graph.
+//
+// See also KonigCover and KonigIndependentSet, which derive a minimum vertex
+// cover and a maximum independent set from the matching.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Bipartite) HopcroftKarp() (mate []NI, size int) {
+	a := g.AdjacencyList
+	mate = make([]NI, len(a))
+	for n := range mate {
+		mate[n] = -1
+	}
+	// dist is BFS layer number of color 0 nodes, -1 for not layered.
+	// distFree is layer number of the (virtual) free node at the end
+	// of shortest augmenting paths, -1 if no augmenting path.
+	dist := make([]int, len(a))
+	var q []NI
+	var distFree int
+	bfs := func() bool {
+		q = q[:0]
+		g.Color.IterateZeros(func(n int) bool {
+			if mate[n] < 0 {
+				dist[n] = 0
+				q = append(q, NI(n))
+			} else {
+				dist[n] = -1
+			}
+			return true
+		})
+		distFree = -1
+		for i := 0; i < len(q); i++ {
+			u := q[i]
+			if distFree >= 0 && dist[u] >= distFree {
+				continue // no need to search beyond shortest paths
+			}
+			for _, v := range a[u] {
+				switch w := mate[v]; {
+				case w < 0:
+					if distFree < 0 {
+						distFree = dist[u] + 1
+					}
+				case dist[w] < 0:
+					dist[w] = dist[u] + 1
+					q = append(q, w)
+				}
+			}
+		}
+		return distFree >= 0
+	}
+	var dfs func(NI) bool
+	dfs = func(u NI) bool {
+		for _, v := range a[u] {
+			w := mate[v]
+			if w < 0 && dist[u]+1 == distFree ||
+				w >= 0 && dist[w] == dist[u]+1 && dfs(w) {
+				mate[u] = v
+				mate[v] = u
+				return true
+			}
+		}
+		dist[u] = -1 // dead end, don't search again this phase
+		return false
+	}
+	for bfs() {
+		g.Color.IterateZeros(func(n int) bool {
+			if mate[n] < 0 && dfs(NI(n)) {
+				size++
+			}
+			return true
+		})
+	}
+	return
+}
+
+// KonigCover derives a minimum vertex cover of a bipartite graph from a
+// maximum matching.
+//
+// Argument mate must be a maximum matching of g, as returned by HopcroftKarp
+// for example.
+//
+// Returned is a bitmap of the nodes of the cover.  Every edge of g has at
+// least one end in the cover.  By König's theorem the number of nodes in the
+// cover is equal to the size of the matching.
+//
+// See also KonigIndependentSet.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Bipartite) KonigCover(mate []NI) bits.Bits {
+	z := g.konigZ(mate)
+	// cover is color 0 nodes not in z and color 1 nodes in z
+	c := bits.New(len(mate))
+	c.Xor(z, g.Color)
+	c.Not(c)
+	return c
+}
+
+// KonigIndependentSet derives a maximum independent set of a bipartite graph
+// from a maximum matching.
+//
+// Argument mate must be a maximum matching of g, as returned by HopcroftKarp
+// for example.
+//
+// Returned is a bitmap of the nodes of the independent set.  No two nodes of
+// the set are connected by an edge of g.  The independent set is the
+// complement of the minimum vertex cover returned by KonigCover.
+//
+// There are equivalent labeled and unlabeled versions of this method.
+func (g Bipartite) KonigIndependentSet(mate []NI) bits.Bits {
+	z := g.konigZ(mate)
+	// independent set is color 0 nodes in z and color 1 nodes not in z
+	i := bits.New(len(mate))
+	i.Xor(z, g.Color)
+	return i
+}
+
+// konigZ returns the nodes reachable by alternating paths from unmatched
+// nodes of color 0.
+func (g Bipartite) konigZ(mate []NI) bits.Bits {
+	a := g.AdjacencyList
+	z := bits.New(len(a))
+	var q []NI
+	g.Color.IterateZeros(func(n int) bool {
+		if mate[n] < 0 {
+			z.SetBit(n, 1)
+			q = append(q, NI(n))
+		}
+		return true
+	})
+	for i := 0; i < len(q); i++ {
+		u := q[i]
+		for _, v := range a[u] {
+			if v == mate[u] || z.Bit(int(v)) == 1 {
+				continue
+			}
+			z.SetBit(int(v), 1)
+			// v is color 1 and must be matched if mate is maximum.
+			if w := mate[v]; w >= 0 && z.Bit(int(w)) == 0 {
+				z.SetBit(int(w), 1)
+				q = append(q, w)
+			}
+		}
+	}
+	return z
+}
+
 // PermuteBiadjacency permutes a bipartite graph in place so that a prefix
 // of the adjacency list encodes a biadjacency matrix.
 //
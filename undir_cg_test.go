@@ -20,6 +20,42 @@ import (
 	"github.com/soniakeys/graph"
 )
 
+func ExampleLabeledUndirected_ApproxVertexCover() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	fmt.Println(g.ApproxVertexCover().Slice())
+	// Output:
+	// [0 1 2 3]
+}
+
+func ExampleLabeledUndirected_AverageClustering() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	fmt.Printf("%.3f\n", g.AverageClustering())
+	// Output:
+	// 0.533
+}
+
 func ExampleLabeledUndirected_Bipartite() {
 	// 0 1 2  5  6
 	//  \|/|     |
@@ -300,6 +336,30 @@ func ExampleLabeledUndirected_ConnectedComponentReps() {
 	// arcSizes: [6 2 0]
 }
 
+func ExampleLabeledUndirected_DSatur() {
+	//   1   ----5
+	//  / \ /   / \
+	// 0---2---4  |
+	//      \   \ /
+	//   3   ----6
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	g.AddEdge(graph.Edge{4, 6}, 0)
+	g.AddEdge(graph.Edge{5, 6}, 0)
+	color, n := g.DSatur()
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 4 colors
+	// [1 2 0 0 1 2 3]
+}
+
 func ExampleLabeledUndirected_Degeneracy() {
 	//   1   ----5
 	//  / \ /   / \
@@ -592,6 +652,92 @@ func ExampleLabeledUndirected_EulerianStart() {
 	// 2
 }
 
+func ExampleLabeledUndirected_ExactColoring() {
+	// A wheel with five spokes.  The rim is an odd cycle.
+	//    1
+	//   /|\
+	//  5-0-2
+	//  |/ \|
+	//  4---3
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	g.AddEdge(graph.Edge{5, 1}, 0)
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{0, 3}, 0)
+	g.AddEdge(graph.Edge{0, 4}, 0)
+	g.AddEdge(graph.Edge{0, 5}, 0)
+	color, n := g.ExactColoring()
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 4 colors
+	// [0 1 2 1 2 3]
+}
+
+func ExampleLabeledUndirected_GreedyColoring() {
+	//   1   ----5
+	//  / \ /   / \
+	// 0---2---4  |
+	//      \   \ /
+	//   3   ----6
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	g.AddEdge(graph.Edge{4, 6}, 0)
+	g.AddEdge(graph.Edge{5, 6}, 0)
+	color, n := g.GreedyColoring(nil)
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 4 colors
+	// [0 1 3 0 0 1 2]
+}
+
+func ExampleLabeledUndirected_GreedyDominatingSet() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	fmt.Println(g.GreedyDominatingSet().Slice())
+	// Output:
+	// [1 2]
+}
+
+func ExampleLabeledUndirected_GreedyIndependentSet() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	fmt.Println(g.GreedyIndependentSet().Slice())
+	// Output:
+	// [0 3 4 5 6]
+}
+
 func ExampleLabeledUndirected_InduceBits() {
 	// undirected graph:
 	//     1
@@ -739,6 +885,104 @@ func ExampleLabeledUndirected_IsTree() {
 	// false false
 }
 
+func ExampleLabeledUndirected_LocalClustering() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	fmt.Printf("%.3f\n", g.LocalClustering())
+	// Output:
+	// [1.000 0.667 0.667 0.333 0.000]
+}
+
+func ExampleLabeledUndirected_MaximumClique() {
+	//   1---2
+	//  /|\ /|
+	// 0 | X |
+	//  \|/ \|
+	//   3---4---5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 3}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{2, 4}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	fmt.Println(g.MaximumClique().Slice())
+	// Output:
+	// [1 2 3 4]
+}
+
+func ExampleLabeledUndirected_MaximumIndependentSet() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	fmt.Println(g.MaximumIndependentSet().Slice())
+	// Output:
+	// [0 3 4 5 6]
+}
+
+func ExampleLabeledUndirected_MaximumWeightClique() {
+	//   1---2
+	//  /|\ /|
+	// 0 | X |
+	//  \|/ \|
+	//   3---4---5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 3}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{2, 4}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	w := []float64{5, 1, 1, 4, 1, 3}
+	c, weight := g.MaximumWeightClique(w)
+	fmt.Println(c.Slice(), weight)
+	// Output:
+	// [0 1 3] 10
+}
+
+func ExampleLabeledUndirected_MinimumVertexCover() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{2, 5}, 0)
+	g.AddEdge(graph.Edge{2, 6}, 0)
+	fmt.Println(g.MinimumVertexCover().Slice())
+	// Output:
+	// [1 2]
+}
+
 func ExampleLabeledUndirected_Size() {
 	//   0--\
 	//  / \-/
@@ -753,6 +997,81 @@ func ExampleLabeledUndirected_Size() {
 	// (Arc size = 3)
 }
 
+func ExampleLabeledUndirected_Transitivity() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	fmt.Println(g.Transitivity())
+	// Output:
+	// 0.6
+}
+
+func ExampleLabeledUndirected_Triangles() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	perNode, total := g.Triangles()
+	fmt.Println("per node:", perNode)
+	fmt.Println("total:", total)
+	// Output:
+	// per node: [1 2 2 1 0]
+	// total: 2
+}
+
+func ExampleLabeledUndirected_TwoEdgeConnectedComponents() {
+	// 0---1   4---5---7===8
+	//  \ /    |   |
+	//   2-----3---6
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{1, 2}, 0)
+	g.AddEdge(graph.Edge{2, 0}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	g.AddEdge(graph.Edge{3, 4}, 0)
+	g.AddEdge(graph.Edge{4, 5}, 0)
+	g.AddEdge(graph.Edge{5, 6}, 0)
+	g.AddEdge(graph.Edge{6, 3}, 0)
+	g.AddEdge(graph.Edge{5, 7}, 0)
+	g.AddEdge(graph.Edge{7, 8}, 0)
+	g.AddEdge(graph.Edge{7, 8}, 0) // parallel
+	ci, nc := g.TwoEdgeConnectedComponents()
+	fmt.Println(nc, "components.")
+	fmt.Println("node  component int")
+	for n, i := range ci {
+		fmt.Println(n, "   ", i)
+	}
+	// Output:
+	// 3 components.
+	// node  component int
+	// 0     1
+	// 1     1
+	// 2     1
+	// 3     2
+	// 4     2
+	// 5     2
+	// 6     2
+	// 7     3
+	// 8     3
+}
+
 func ExampleLabeledUndirectedSubgraph_AddNode() {
 	// supergraph:
 	//    0
@@ -827,6 +1146,56 @@ func ExampleLabeledBipartite_Density() {
 	// 0.67
 }
 
+func ExampleLabeledBipartite_HopcroftKarp() {
+	// 0 1 2
+	// |\|/
+	// 4 3
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 3}, 0)
+	g.AddEdge(graph.Edge{0, 4}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	b, _, _ := g.Bipartite()
+	mate, size := b.HopcroftKarp()
+	fmt.Println("mate:", mate)
+	fmt.Println("size:", size)
+	// Output:
+	// mate: [4 3 -1 1 0]
+	// size: 2
+}
+
+func ExampleLabeledBipartite_KonigCover() {
+	// 0 1 2
+	// |\|/
+	// 4 3
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 3}, 0)
+	g.AddEdge(graph.Edge{0, 4}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	b, _, _ := g.Bipartite()
+	mate, _ := b.HopcroftKarp()
+	fmt.Println(b.KonigCover(mate).Slice())
+	// Output:
+	// [0 3]
+}
+
+func ExampleLabeledBipartite_KonigIndependentSet() {
+	// 0 1 2
+	// |\|/
+	// 4 3
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 3}, 0)
+	g.AddEdge(graph.Edge{0, 4}, 0)
+	g.AddEdge(graph.Edge{1, 3}, 0)
+	g.AddEdge(graph.Edge{2, 3}, 0)
+	b, _, _ := g.Bipartite()
+	mate, _ := b.HopcroftKarp()
+	fmt.Println(b.KonigIndependentSet(mate).Slice())
+	// Output:
+	// [1 2 4]
+}
+
 func ExampleLabeledBipartite_PermuteBiadjacency() {
 	// 3 1 4
 	//  \|/|
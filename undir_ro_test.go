@@ -16,13 +16,51 @@ package graph_test
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
+	"testing"
 	"text/template"
 
 	"github.com/soniakeys/bits"
 	"github.com/soniakeys/graph"
 )
 
+func ExampleUndirected_ApproxVertexCover() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	fmt.Println(g.ApproxVertexCover().Slice())
+	// Output:
+	// [0 1 2 3]
+}
+
+func ExampleUndirected_AverageClustering() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	fmt.Printf("%.3f\n", g.AverageClustering())
+	// Output:
+	// 0.533
+}
+
 func ExampleUndirected_Bipartite() {
 	// 0 1 2  5  6
 	//  \|/|     |
@@ -303,6 +341,30 @@ func ExampleUndirected_ConnectedComponentReps() {
 	// arcSizes: [6 2 0]
 }
 
+func ExampleUndirected_DSatur() {
+	//   1   ----5
+	//  / \ /   / \
+	// 0---2---4  |
+	//      \   \ /
+	//   3   ----6
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	g.AddEdge(4, 5)
+	g.AddEdge(4, 6)
+	g.AddEdge(5, 6)
+	color, n := g.DSatur()
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 4 colors
+	// [1 2 0 0 1 2 3]
+}
+
 func ExampleUndirected_Degeneracy() {
 	//   1   ----5
 	//  / \ /   / \
@@ -587,6 +649,92 @@ func ExampleUndirected_EulerianStart() {
 	// 2
 }
 
+func ExampleUndirected_ExactColoring() {
+	// A wheel with five spokes.  The rim is an odd cycle.
+	//    1
+	//   /|\
+	//  5-0-2
+	//  |/ \|
+	//  4---3
+	var g graph.Undirected
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 1)
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(0, 3)
+	g.AddEdge(0, 4)
+	g.AddEdge(0, 5)
+	color, n := g.ExactColoring()
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 4 colors
+	// [0 1 2 1 2 3]
+}
+
+func ExampleUndirected_GreedyColoring() {
+	//   1   ----5
+	//  / \ /   / \
+	// 0---2---4  |
+	//      \   \ /
+	//   3   ----6
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	g.AddEdge(4, 5)
+	g.AddEdge(4, 6)
+	g.AddEdge(5, 6)
+	color, n := g.GreedyColoring(nil)
+	fmt.Println(n, "colors")
+	fmt.Println(color)
+	// Output:
+	// 4 colors
+	// [0 1 3 0 0 1 2]
+}
+
+func ExampleUndirected_GreedyDominatingSet() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	fmt.Println(g.GreedyDominatingSet().Slice())
+	// Output:
+	// [1 2]
+}
+
+func ExampleUndirected_GreedyIndependentSet() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	fmt.Println(g.GreedyIndependentSet().Slice())
+	// Output:
+	// [0 3 4 5 6]
+}
+
 func ExampleUndirected_InduceBits() {
 	// undirected graph:
 	//   1
@@ -717,6 +865,104 @@ func ExampleUndirected_IsTree() {
 	// false false
 }
 
+func ExampleUndirected_LocalClustering() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	fmt.Printf("%.3f\n", g.LocalClustering())
+	// Output:
+	// [1.000 0.667 0.667 0.333 0.000]
+}
+
+func ExampleUndirected_MaximumClique() {
+	//   1---2
+	//  /|\ /|
+	// 0 | X |
+	//  \|/ \|
+	//   3---4---5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	fmt.Println(g.MaximumClique().Slice())
+	// Output:
+	// [1 2 3 4]
+}
+
+func ExampleUndirected_MaximumIndependentSet() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	fmt.Println(g.MaximumIndependentSet().Slice())
+	// Output:
+	// [0 3 4 5 6]
+}
+
+func ExampleUndirected_MaximumWeightClique() {
+	//   1---2
+	//  /|\ /|
+	// 0 | X |
+	//  \|/ \|
+	//   3---4---5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	w := []float64{5, 1, 1, 4, 1, 3}
+	c, weight := g.MaximumWeightClique(w)
+	fmt.Println(c.Slice(), weight)
+	// Output:
+	// [0 1 3] 10
+}
+
+func ExampleUndirected_MinimumVertexCover() {
+	//     4   6
+	//     |   |
+	// 0---1---2---3
+	//         |
+	//         5
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 6)
+	fmt.Println(g.MinimumVertexCover().Slice())
+	// Output:
+	// [1 2]
+}
+
 func ExampleUndirected_Size() {
 	//   0--\
 	//  / \-/
@@ -731,6 +977,81 @@ func ExampleUndirected_Size() {
 	// (Arc size = 3)
 }
 
+func ExampleUndirected_Transitivity() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	fmt.Println(g.Transitivity())
+	// Output:
+	// 0.6
+}
+
+func ExampleUndirected_Triangles() {
+	// 0---1
+	// |  /|
+	// | / |
+	// |/  |
+	// 2---3---4
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	perNode, total := g.Triangles()
+	fmt.Println("per node:", perNode)
+	fmt.Println("total:", total)
+	// Output:
+	// per node: [1 2 2 1 0]
+	// total: 2
+}
+
+func ExampleUndirected_TwoEdgeConnectedComponents() {
+	// 0---1   4---5---7===8
+	//  \ /    |   |
+	//   2-----3---6
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 6)
+	g.AddEdge(6, 3)
+	g.AddEdge(5, 7)
+	g.AddEdge(7, 8)
+	g.AddEdge(7, 8) // parallel
+	ci, nc := g.TwoEdgeConnectedComponents()
+	fmt.Println(nc, "components.")
+	fmt.Println("node  component int")
+	for n, i := range ci {
+		fmt.Println(n, "   ", i)
+	}
+	// Output:
+	// 3 components.
+	// node  component int
+	// 0     1
+	// 1     1
+	// 2     1
+	// 3     2
+	// 4     2
+	// 5     2
+	// 6     2
+	// 7     3
+	// 8     3
+}
+
 func ExampleUndirectedSubgraph_AddNode() {
 	// supergraph:
 	//    0
@@ -806,6 +1127,113 @@ func ExampleBipartite_Density() {
 	// Output:
 	// 0.67
 }
+
+func ExampleBipartite_HopcroftKarp() {
+	// 0 1 2
+	// |\|/
+	// 4 3
+	var g graph.Undirected
+	g.AddEdge(0, 3)
+	g.AddEdge(0, 4)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	b, _, _ := g.Bipartite()
+	mate, size := b.HopcroftKarp()
+	fmt.Println("mate:", mate)
+	fmt.Println("size:", size)
+	// Output:
+	// mate: [4 3 -1 1 0]
+	// size: 2
+}
+
+func ExampleBipartite_KonigCover() {
+	// 0 1 2
+	// |\|/
+	// 4 3
+	var g graph.Undirected
+	g.AddEdge(0, 3)
+	g.AddEdge(0, 4)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	b, _, _ := g.Bipartite()
+	mate, _ := b.HopcroftKarp()
+	fmt.Println(b.KonigCover(mate).Slice())
+	// Output:
+	// [0 3]
+}
+
+func ExampleBipartite_KonigIndependentSet() {
+	// 0 1 2
+	// |\|/
+	// 4 3
+	var g graph.Undirected
+	g.AddEdge(0, 3)
+	g.AddEdge(0, 4)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	b, _, _ := g.Bipartite()
+	mate, _ := b.HopcroftKarp()
+	fmt.Println(b.KonigIndependentSet(mate).Slice())
+	// Output:
+	// [1 2 4]
+}
+
+// TestHopcroftKarp validates matchings on random bipartite graphs.
+//
+// A vertex cover the same size as a matching proves the matching maximum
+// and the cover minimum.
+func TestHopcroftKarp(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 50; i++ {
+		n0 := 1 + r.Intn(20)
+		n1 := 1 + r.Intn(20)
+		g := graph.Undirected{make(graph.AdjacencyList, n0+n1)}
+		for m := r.Intn(n0 * n1); m > 0; m-- {
+			g.AddEdge(graph.NI(r.Intn(n0)), graph.NI(n0+r.Intn(n1)))
+		}
+		c := bits.New(n0 + n1)
+		for n := n0; n < n0+n1; n++ {
+			c.SetBit(n, 1)
+		}
+		b := graph.Bipartite{g, c, n0}
+		mate, size := b.HopcroftKarp()
+		nm := 0
+		for n, m := range mate {
+			if m < 0 {
+				continue
+			}
+			nm++
+			if mate[m] != graph.NI(n) {
+				t.Fatal("mate not symmetric")
+			}
+			if has, _ := g.HasArc(graph.NI(n), m); !has {
+				t.Fatal("matched nodes not adjacent")
+			}
+		}
+		if nm != 2*size {
+			t.Fatal("size", size, "but", nm, "nodes matched")
+		}
+		cover := b.KonigCover(mate)
+		if cover.OnesCount() != size {
+			t.Fatal("cover size", cover.OnesCount(), "matching size", size)
+		}
+		ind := b.KonigIndependentSet(mate)
+		if ind.OnesCount() != n0+n1-size {
+			t.Fatal("independent set size", ind.OnesCount())
+		}
+		for fr, to := range g.AdjacencyList {
+			for _, to := range to {
+				if cover.Bit(fr) == 0 && cover.Bit(int(to)) == 0 {
+					t.Fatal("edge", fr, to, "not covered")
+				}
+				if ind.Bit(fr) == 1 && ind.Bit(int(to)) == 1 {
+					t.Fatal("edge", fr, to, "in independent set")
+				}
+			}
+		}
+	}
+}
+
 func ExampleBipartite_PermuteBiadjacency() {
 	// 3 1 4
 	//  \|/|
@@ -843,3 +1271,318 @@ func ExampleBipartite_PermuteBiadjacency() {
 	// Color 11100
 	// N0    2
 }
+
+// TestColoring checks that colorings are proper and that ExactColoring
+// finds the chromatic number, by comparison with a simple exhaustive search.
+func TestColoring(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(14)
+		g := graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r)
+		check := func(name string, color []int, nc int) {
+			used := make([]bool, nc)
+			for fr, to := range g.AdjacencyList {
+				if color[fr] < 0 || color[fr] >= nc {
+					t.Fatal(name, "color out of range")
+				}
+				used[color[fr]] = true
+				for _, to := range to {
+					if color[fr] == color[to] {
+						t.Fatal(name, "adjacent nodes same color")
+					}
+				}
+			}
+			for _, u := range used {
+				if !u {
+					t.Fatal(name, "color unused")
+				}
+			}
+		}
+		gc, gn := g.GreedyColoring(nil)
+		check("GreedyColoring", gc, gn)
+		dc, dn := g.DSatur()
+		check("DSatur", dc, dn)
+		ec, en := g.ExactColoring()
+		check("ExactColoring", ec, en)
+		// exhaustive search for the chromatic number.  node v may use
+		// colors up to m, one more than the colors used so far.
+		c := make([]int, n)
+		var colorable func(k, v, m int) bool
+		colorable = func(k, v, m int) bool {
+			if v == n {
+				return true
+			}
+		next:
+			for c[v] = 0; c[v] < k && c[v] <= m; c[v]++ {
+				for _, to := range g.AdjacencyList[v] {
+					if int(to) < v && c[to] == c[v] {
+						continue next
+					}
+				}
+				nm := m
+				if c[v] == m {
+					nm++
+				}
+				if colorable(k, v+1, nm) {
+					return true
+				}
+			}
+			return false
+		}
+		k := 1
+		for !colorable(k, 0, 0) {
+			k++
+		}
+		if en != k {
+			t.Fatal("ExactColoring", en, "chromatic number", k)
+		}
+		if gn < en || dn < en {
+			t.Fatal("heuristic better than exact")
+		}
+		// parallel edges do not change DSatur saturation or degree
+		var h graph.Undirected
+		h.AdjacencyList = make(graph.AdjacencyList, n)
+		g.Edges(func(e graph.Edge) {
+			for j := 1 + r.Intn(3); j > 0; j-- {
+				h.AddEdge(e.N1, e.N2)
+			}
+		})
+		if hc, hn := h.DSatur(); hn != dn || fmt.Sprint(hc) != fmt.Sprint(dc) {
+			t.Fatal("DSatur with parallel edges", hc, "want", dc)
+		}
+	}
+}
+
+// TestTriangles compares Triangles, LocalClustering, and Transitivity, and
+// Directed.Triangles, with counts from adjacency matrices on small random
+// graphs with loops and parallel arcs.
+func TestTriangles(t *testing.T) {
+	r := rand.New(rand.NewSource(59))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(12)
+		var g graph.Undirected
+		g.AdjacencyList = make(graph.AdjacencyList, n)
+		d := make(graph.AdjacencyList, n)
+		for e := r.Intn(3 * n); e > 0; e-- {
+			u, v := graph.NI(r.Intn(n)), graph.NI(r.Intn(n))
+			g.AddEdge(u, v)
+			d[u] = append(d[u], v)
+		}
+		adj := make([][]bool, n)
+		arc := make([][]bool, n)
+		for v := range adj {
+			adj[v] = make([]bool, n)
+			arc[v] = make([]bool, n)
+		}
+		for fr, to := range g.AdjacencyList {
+			for _, to := range to {
+				adj[fr][to] = int(to) != fr
+			}
+		}
+		for fr, to := range d {
+			for _, to := range to {
+				arc[fr][to] = int(to) != fr
+			}
+		}
+		perNode := make([]int, n)
+		deg := make([]int, n)
+		var total, triples, cycle, flow int
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if adj[u][v] {
+					deg[u]++
+				}
+				for w := 0; w < n; w++ {
+					if adj[u][v] && adj[v][w] && adj[u][w] {
+						perNode[u]++ // counted for 2 orders of v, w
+						if u < v && v < w {
+							total++
+						}
+					}
+					if arc[u][v] && arc[v][w] && arc[w][u] && u < v && u < w {
+						cycle++
+					}
+					if arc[u][v] && arc[v][w] && arc[u][w] {
+						flow++
+					}
+				}
+			}
+			perNode[u] /= 2
+			triples += deg[u] * (deg[u] - 1) / 2
+		}
+		gp, gt := g.Triangles()
+		if gt != total || fmt.Sprint(gp) != fmt.Sprint(perNode) {
+			t.Fatal("Triangles", gp, gt, "want", perNode, total)
+		}
+		lc := g.LocalClustering()
+		for v, c := range lc {
+			want := 0.
+			if deg[v] > 1 {
+				want = float64(perNode[v]) / float64(deg[v]*(deg[v]-1)/2)
+			}
+			if c != want {
+				t.Fatal("LocalClustering", lc, "node", v, "want", want)
+			}
+		}
+		want := 0.
+		if triples > 0 {
+			want = 3 * float64(total) / float64(triples)
+		}
+		if tr := g.Transitivity(); tr != want {
+			t.Fatal("Transitivity", tr, "want", want)
+		}
+		dc, df := graph.Directed{d}.Triangles()
+		if dc != cycle || df != flow {
+			t.Fatal("Directed.Triangles", dc, df, "want", cycle, flow)
+		}
+	}
+}
+
+// TestMaximumClique compares MaximumClique with the largest clique found by
+// BronKerbosch3 and MaximumWeightClique with an exhaustive search.
+func TestMaximumClique(t *testing.T) {
+	r := rand.New(rand.NewSource(67))
+	for i := 0; i < 200; i++ {
+		n := r.Intn(15)
+		g := graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r)
+		isClique := func(c bits.Bits) bool {
+			ok := true
+			c.IterateOnes(func(u int) bool {
+				c.IterateOnes(func(v int) bool {
+					if u != v {
+						if has, _, _ := g.HasEdge(graph.NI(u), graph.NI(v)); !has {
+							ok = false
+						}
+					}
+					return ok
+				})
+				return ok
+			})
+			return ok
+		}
+		c := g.MaximumClique()
+		if !isClique(c) {
+			t.Fatal("not a clique", c.Slice())
+		}
+		max := 0
+		g.BronKerbosch3(g.BKPivotMaxDegree, func(c bits.Bits) bool {
+			if s := c.OnesCount(); s > max {
+				max = s
+			}
+			return true
+		})
+		if s := c.OnesCount(); s != max {
+			t.Fatal("MaximumClique", s, "want", max)
+		}
+		w := make([]float64, n)
+		for v := range w {
+			w[v] = float64(r.Intn(10))
+		}
+		wc, ww := g.MaximumWeightClique(w)
+		if !isClique(wc) {
+			t.Fatal("not a clique", wc.Slice())
+		}
+		var sum float64
+		wc.IterateOnes(func(v int) bool {
+			sum += w[v]
+			return true
+		})
+		if sum != ww {
+			t.Fatal("weight", ww, "want", sum)
+		}
+		// exhaustive search over node subsets
+		var best float64
+		s := bits.New(n)
+		for m := 0; m < 1<<uint(n); m++ {
+			var sw float64
+			for v := 0; v < n; v++ {
+				s.SetBit(v, m>>uint(v)&1)
+				sw += float64(m>>uint(v)&1) * w[v]
+			}
+			if sw > best && isClique(s) {
+				best = sw
+			}
+		}
+		if ww != best {
+			t.Fatal("MaximumWeightClique", ww, "want", best)
+		}
+	}
+}
+
+func TestVertexCover(t *testing.T) {
+	r := rand.New(rand.NewSource(71))
+	for i := 0; i < 300; i++ {
+		n := r.Intn(15)
+		g := graph.GnmUndirected(n, r.Intn(n*(n-1)/2+1), r)
+		// a few loops and parallel edges
+		for j := r.Intn(3); n > 0 && j > 0; j-- {
+			g.AddEdge(graph.NI(r.Intn(n)), graph.NI(r.Intn(n)))
+		}
+		isCover := func(c bits.Bits) bool {
+			for fr, to := range g.AdjacencyList {
+				for _, to := range to {
+					if c.Bit(fr) == 0 && c.Bit(int(to)) == 0 {
+						return false
+					}
+				}
+			}
+			return true
+		}
+		// exhaustive search for the size of a minimum cover
+		min := n
+		s := bits.New(n)
+		for m := 0; m < 1<<uint(n); m++ {
+			for v := 0; v < n; v++ {
+				s.SetBit(v, m>>uint(v)&1)
+			}
+			if c := s.OnesCount(); c < min && isCover(s) {
+				min = c
+			}
+		}
+		c := g.MinimumVertexCover()
+		if !isCover(c) {
+			t.Fatal("not a cover", c.Slice())
+		}
+		if c.OnesCount() != min {
+			t.Fatal("MinimumVertexCover", c.OnesCount(), "want", min)
+		}
+		a := g.ApproxVertexCover()
+		if !isCover(a) || a.OnesCount() > 2*min {
+			t.Fatal("ApproxVertexCover", a.Slice(), "min", min)
+		}
+		// an independent set is the complement of a cover
+		is := g.MaximumIndependentSet()
+		s.SetAll()
+		s.AndNot(s, is)
+		if !isCover(s) || is.OnesCount() != n-min {
+			t.Fatal("MaximumIndependentSet", is.Slice())
+		}
+		gi := g.GreedyIndependentSet()
+		s.SetAll()
+		s.AndNot(s, gi)
+		if !isCover(s) {
+			t.Fatal("GreedyIndependentSet not independent", gi.Slice())
+		}
+		for v := 0; v < n; v++ {
+			if gi.Bit(v) == 0 {
+				gi.SetBit(v, 1)
+				s.SetBit(v, 0)
+				if isCover(s) {
+					t.Fatal("GreedyIndependentSet not maximal")
+				}
+				gi.SetBit(v, 0)
+				s.SetBit(v, 1)
+			}
+		}
+		d := g.GreedyDominatingSet()
+		for fr, to := range g.AdjacencyList {
+			dom := d.Bit(fr) == 1
+			for _, to := range to {
+				dom = dom || d.Bit(int(to)) == 1
+			}
+			if !dom {
+				t.Fatal("GreedyDominatingSet", d.Slice(), "does not dominate", fr)
+			}
+		}
+	}
+}
@@ -5,7 +5,12 @@ package graph_test
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
 
+	"github.com/soniakeys/bits"
 	"github.com/soniakeys/graph"
 )
 
@@ -430,6 +435,139 @@ func ExampleUndirected_BlockCut() {
 	// Articulation Point: 1
 }
 
+func ExampleUndirected_Bridges() {
+	// 0---1   4---5---7===8
+	//  \ /    |   |
+	//   2-----3---6
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 0)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 6)
+	g.AddEdge(6, 3)
+	g.AddEdge(5, 7)
+	g.AddEdge(7, 8)
+	g.AddEdge(7, 8) // parallel
+	g.Bridges(func(e graph.Edge) bool {
+		fmt.Println(e)
+		return true
+	})
+	// Output:
+	// {5 7}
+	// {2 3}
+}
+
+func ExampleUndirected_BlockCutTree() {
+	// undirected edges:
+	// 3---2---1---7---9
+	//  \ / \ / \   \ /
+	//   4   5---6   8
+	var g graph.Undirected
+	g.AddEdge(3, 4)
+	g.AddEdge(3, 2)
+	g.AddEdge(2, 4)
+	g.AddEdge(2, 5)
+	g.AddEdge(2, 1)
+	g.AddEdge(5, 1)
+	g.AddEdge(6, 1)
+	g.AddEdge(6, 5)
+	g.AddEdge(7, 1)
+	g.AddEdge(7, 9)
+	g.AddEdge(7, 8)
+	g.AddEdge(9, 8)
+	t, blocks, cuts, treeNode := g.BlockCutTree()
+	fmt.Println("blocks:", blocks)
+	fmt.Println("cuts:  ", cuts)
+	fmt.Println("tree:")
+	for n, to := range t.AdjacencyList {
+		fmt.Println(n, to)
+	}
+	// which cut nodes separate 3 and 9?
+	f := graph.NewFromList(t.Order())
+	t.SpanTree(treeNode[3], &f)
+	fmt.Print("cut nodes between 3 and 9:")
+	for _, n := range f.PathTo(treeNode[9], nil) {
+		if c := int(n) - len(blocks); c >= 0 {
+			fmt.Print(" ", cuts[c])
+		}
+	}
+	fmt.Println()
+	// Output:
+	// blocks: [[2 3 4] [1 2 5 6] [7 8 9] [1 7] [0]]
+	// cuts:   [2 7 1]
+	// tree:
+	// 0 [5]
+	// 1 [7 5]
+	// 2 [6]
+	// 3 [7 6]
+	// 4 []
+	// 5 [0 1]
+	// 6 [2 3]
+	// 7 [1 3]
+	// cut nodes between 3 and 9: 2 1 7
+}
+
+func ExampleUndirected_TrussNumbers() {
+	// 0---1
+	// |\ /|
+	// | X |
+	// |/ \|
+	// 2---3---4
+	//      \ /
+	//       5---6
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(0, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(3, 5)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 6)
+	fmt.Println(g.TrussNumbers())
+	// Output:
+	// map[{0 1}:4 {0 2}:4 {0 3}:4 {1 2}:4 {1 3}:4 {2 3}:4 {3 4}:3 {3 5}:3 {4 5}:3 {5 6}:2]
+}
+
+func ExampleUndirected_KTruss() {
+	// 0---1
+	// |\ /|
+	// | X |
+	// |/ \|
+	// 2---3---4
+	//      \ /
+	//       5---6
+	var g graph.Undirected
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(0, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 3)
+	g.AddEdge(3, 4)
+	g.AddEdge(3, 5)
+	g.AddEdge(4, 5)
+	g.AddEdge(5, 6)
+	s := g.KTruss(3)
+	fmt.Println("SuperNI:", s.SuperNI)
+	for n, to := range s.AdjacencyList {
+		fmt.Println(n, to)
+	}
+	// Output:
+	// SuperNI: [0 1 2 3 4 5]
+	// 0 [1 2 3]
+	// 1 [0 2 3]
+	// 2 [0 1 3]
+	// 3 [0 1 2 4 5]
+	// 4 [3 5]
+	// 5 [3 4]
+}
+
 func ExampleLabeledUndirected_AddEdge() {
 	//       --0--
 	//      /     \\6001
@@ -672,6 +810,395 @@ func ExampleLabeledUndirected_TarjanBiconnectedComponents() {
 	// {1 7}
 }
 
+func ExampleLabeledUndirected_Bridges() {
+	// edges are shown with labels
+	// 0--(10)--1--(11)--2
+	//  \       |
+	//  (12)  (13)
+	//    \     |
+	//     \----3--(14)--4
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 10)
+	g.AddEdge(graph.Edge{1, 2}, 11)
+	g.AddEdge(graph.Edge{0, 3}, 12)
+	g.AddEdge(graph.Edge{1, 3}, 13)
+	g.AddEdge(graph.Edge{3, 4}, 14)
+	g.Bridges(func(e graph.LabeledEdge) bool {
+		fmt.Println(e.N1, e.N2, "label", e.LI)
+		return true
+	})
+	// Output:
+	// 1 2 label 11
+	// 3 4 label 14
+}
+
+func ExampleLabeledUndirected_TrussNumbers() {
+	// Edges are labeled in order of construction.
+	// 0---1
+	// |\ /|
+	// | X |
+	// |/ \|
+	// 2---3---4
+	//      \ /
+	//       5---6
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 1)
+	g.AddEdge(graph.Edge{0, 3}, 2)
+	g.AddEdge(graph.Edge{1, 2}, 3)
+	g.AddEdge(graph.Edge{1, 3}, 4)
+	g.AddEdge(graph.Edge{2, 3}, 5)
+	g.AddEdge(graph.Edge{3, 4}, 6)
+	g.AddEdge(graph.Edge{3, 5}, 7)
+	g.AddEdge(graph.Edge{4, 5}, 8)
+	g.AddEdge(graph.Edge{5, 6}, 9)
+	t := g.TrussNumbers()
+	for _, x := range []graph.LabeledEdge{
+		{graph.Edge{0, 1}, 0},
+		{graph.Edge{3, 4}, 6},
+		{graph.Edge{5, 6}, 9},
+	} {
+		fmt.Println(x, t[x])
+	}
+	// Output:
+	// {{0 1} 0} 4
+	// {{3 4} 6} 3
+	// {{5 6} 9} 2
+}
+
+func ExampleLabeledUndirected_KTruss() {
+	// 0---1
+	// |\ /|
+	// | X |
+	// |/ \|
+	// 2---3---4
+	//      \ /
+	//       5---6
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 0)
+	g.AddEdge(graph.Edge{0, 2}, 1)
+	g.AddEdge(graph.Edge{0, 3}, 2)
+	g.AddEdge(graph.Edge{1, 2}, 3)
+	g.AddEdge(graph.Edge{1, 3}, 4)
+	g.AddEdge(graph.Edge{2, 3}, 5)
+	g.AddEdge(graph.Edge{3, 4}, 6)
+	g.AddEdge(graph.Edge{3, 5}, 7)
+	g.AddEdge(graph.Edge{4, 5}, 8)
+	g.AddEdge(graph.Edge{5, 6}, 9)
+	s := g.KTruss(4)
+	fmt.Println("SuperNI:", s.SuperNI)
+	for n, to := range s.LabeledAdjacencyList {
+		fmt.Println(n, to)
+	}
+	// Output:
+	// SuperNI: [0 1 2 3]
+	// 0 [{1 0} {2 1} {3 2}]
+	// 1 [{0 0} {2 3} {3 4}]
+	// 2 [{0 1} {1 3} {3 5}]
+	// 3 [{0 2} {1 4} {2 5}]
+}
+
+func ExampleLabeledBipartite_MinCostMatching() {
+	// workers 0, 1, 2 and tasks 3, 4, 5, 6.  edge labels are costs.
+	//  task:  3  4  5  6
+	// worker 0:  4  1  3
+	// worker 1:  2  0  5
+	// worker 2:  3  2  2  9
+	var g graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 3}, 4)
+	g.AddEdge(graph.Edge{0, 4}, 1)
+	g.AddEdge(graph.Edge{0, 5}, 3)
+	g.AddEdge(graph.Edge{1, 3}, 2)
+	g.AddEdge(graph.Edge{1, 4}, 0)
+	g.AddEdge(graph.Edge{1, 5}, 5)
+	g.AddEdge(graph.Edge{2, 3}, 3)
+	g.AddEdge(graph.Edge{2, 4}, 2)
+	g.AddEdge(graph.Edge{2, 5}, 2)
+	g.AddEdge(graph.Edge{2, 6}, 9)
+	b, _, _ := g.Bipartite()
+	w := func(l graph.LI) float64 { return float64(l) }
+	match, cost, ok := b.MinCostMatching(w)
+	for n, m := range match {
+		fmt.Println(n, m)
+	}
+	fmt.Println("cost:", cost, "complete:", ok)
+	// Output:
+	// 0 {4 1}
+	// 1 {3 2}
+	// 2 {5 2}
+	// 3 {1 2}
+	// 4 {0 1}
+	// 5 {2 2}
+	// 6 {-1 -1}
+	// cost: 5 complete: true
+}
+
+func ExampleLabeledBipartite_MinCostMatching_infeasible() {
+	// workers 0 and 1 can only do task 2.
+	// 0   1
+	//  \ /
+	//   2   3
+	g := graph.LabeledUndirected{make(graph.LabeledAdjacencyList, 4)}
+	g.AddEdge(graph.Edge{0, 2}, 5)
+	g.AddEdge(graph.Edge{1, 2}, 3)
+	b := graph.LabeledBipartite{g, bits.NewGivens(2, 3), 2}
+	w := func(l graph.LI) float64 { return float64(l) }
+	match, cost, ok := b.MinCostMatching(w)
+	fmt.Println(match)
+	fmt.Println("cost:", cost, "complete:", ok)
+	// Output:
+	// [{-1 -1} {2 3} {1 3} {-1 -1}]
+	// cost: 3 complete: false
+}
+
+// TestMinCostMatching compares MinCostMatching to exhaustive search on small
+// random bipartite graphs.
+func TestMinCostMatching(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 200; i++ {
+		n0 := 1 + r.Intn(5)
+		n1 := 1 + r.Intn(5)
+		g := graph.LabeledUndirected{make(graph.LabeledAdjacencyList, n0+n1)}
+		var wt []float64
+		for m := r.Intn(n0*n1 + 3); m > 0; m-- {
+			e := graph.Edge{graph.NI(r.Intn(n0)), graph.NI(n0 + r.Intn(n1))}
+			g.AddEdge(e, graph.LI(len(wt)))
+			wt = append(wt, float64(r.Intn(21)-5))
+		}
+		c := bits.New(n0 + n1)
+		for n := n0; n < n0+n1; n++ {
+			c.SetBit(n, 1)
+		}
+		b := graph.LabeledBipartite{g, c, n0}
+		w := func(l graph.LI) float64 { return wt[l] }
+		match, cost, ok := b.MinCostMatching(w)
+		// validate returned matching
+		size := 0
+		for n, m := range match[:n0] {
+			if m.To < 0 {
+				continue
+			}
+			size++
+			if match[m.To] != (graph.Half{graph.NI(n), m.Label}) {
+				t.Fatal("match not symmetric")
+			}
+			if has, _ := g.HasArcLabel(graph.NI(n), m.To, m.Label); !has {
+				t.Fatal("matched edge not in graph")
+			}
+		}
+		// exhaustive search
+		bestSize, bestCost := 0, math.Inf(1)
+		used := bits.New(n0 + n1)
+		var f func(u, size int, cost float64)
+		f = func(u, size int, cost float64) {
+			if u == n0 {
+				if size > bestSize || size == bestSize && cost < bestCost {
+					bestSize, bestCost = size, cost
+				}
+				return
+			}
+			f(u+1, size, cost)
+			for _, h := range g.LabeledAdjacencyList[u] {
+				if used.Bit(int(h.To)) == 0 {
+					used.SetBit(int(h.To), 1)
+					f(u+1, size+1, cost+wt[h.Label])
+					used.SetBit(int(h.To), 0)
+				}
+			}
+		}
+		f(0, 0, 0)
+		if bestSize == 0 {
+			bestCost = 0
+		}
+		if size != bestSize || cost != bestCost {
+			t.Fatal("got", size, cost, "want", bestSize, bestCost)
+		}
+		small := n0
+		if n1 < small {
+			small = n1
+		}
+		if ok != (size == small) {
+			t.Fatal("ok", ok, "size", size)
+		}
+	}
+}
+
+// TestBridges checks Bridges against removing each edge and counting
+// components, and checks that TwoEdgeConnectedComponents are the connected
+// components remaining after removing bridges.
+func TestBridges(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(20)
+		g := graph.Undirected{make(graph.AdjacencyList, n)}
+		for e := r.Intn(n * 2); e > 0; e-- {
+			g.AddEdge(graph.NI(r.Intn(n)), graph.NI(r.Intn(n)))
+		}
+		_, nc := g.ConnectedComponentInts()
+		var want []graph.Edge
+		g.Edges(func(e graph.Edge) {
+			c, _ := g.Copy()
+			c.RemoveEdge(e.N1, e.N2)
+			if _, nc2 := c.ConnectedComponentInts(); nc2 > nc {
+				want = append(want, e)
+			}
+		})
+		nb := 0
+		c, _ := g.Copy()
+		g.Bridges(func(e graph.Edge) bool {
+			nb++
+			found := false
+			for _, w := range want {
+				if w == e || w == (graph.Edge{e.N2, e.N1}) {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatal("not a bridge", e)
+			}
+			c.RemoveEdge(e.N1, e.N2)
+			return true
+		})
+		if nb != len(want) {
+			t.Fatal("bridges", nb, "want", len(want))
+		}
+		wci, wnc := c.ConnectedComponentInts()
+		gci, gnc := g.TwoEdgeConnectedComponents()
+		if gnc != wnc || !reflect.DeepEqual(gci, wci) {
+			t.Fatal("TwoEdgeConnectedComponents", gci, wci)
+		}
+	}
+}
+
+// TestBlockCutTree checks that BlockCutTree results are forests with
+// consistent mappings.
+func TestBlockCutTree(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for i := 0; i < 100; i++ {
+		n := 1 + r.Intn(20)
+		g := graph.GnmUndirected(n, r.Intn(n*3/2+1), r)
+		tr, blocks, cuts, treeNode := g.BlockCutTree()
+		if tr.Order() != len(blocks)+len(cuts) {
+			t.Fatal("tree order")
+		}
+		if _, _, forest := tr.FromList(); !forest {
+			t.Fatal("not a forest")
+		}
+		_, gnc := g.ConnectedComponentInts()
+		if _, tnc := tr.ConnectedComponentInts(); tnc != gnc {
+			t.Fatal("components", tnc, "want", gnc)
+		}
+		count := make([]int, n)
+		for b, nodes := range blocks {
+			for _, nd := range nodes {
+				count[nd]++
+				if treeNode[nd] != graph.NI(b) &&
+					treeNode[nd] < graph.NI(len(blocks)) {
+					t.Fatal("treeNode", nd)
+				}
+			}
+		}
+		for c, nd := range cuts {
+			if count[nd] < 2 || treeNode[nd] != graph.NI(len(blocks)+c) {
+				t.Fatal("cut", nd)
+			}
+			count[nd] = 1
+		}
+		for nd, c := range count {
+			if c != 1 {
+				t.Fatal("node", nd, "in", c, "blocks")
+			}
+		}
+	}
+}
+
+// TestTrussNumbers compares TrussNumbers with k-trusses found by repeatedly
+// removing edges in too few triangles, and checks KTruss subgraphs.
+func TestTrussNumbers(t *testing.T) {
+	r := rand.New(rand.NewSource(61))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(12)
+		var g graph.LabeledUndirected
+		g.LabeledAdjacencyList = make(graph.LabeledAdjacencyList, n)
+		for e := r.Intn(4 * n); e > 0; e-- {
+			g.AddEdge(graph.Edge{graph.NI(r.Intn(n)), graph.NI(r.Intn(n))},
+				graph.LI(r.Intn(2)))
+		}
+		u := graph.Undirected{g.Unlabeled()}
+		tn := u.TrussNumbers()
+		// adjacency matrix of the simple graph
+		adj := make([][]bool, n)
+		for v := range adj {
+			adj[v] = make([]bool, n)
+		}
+		var edges []graph.Edge
+		for fr, to := range u.AdjacencyList {
+			for _, to := range to {
+				if graph.NI(fr) < to && !adj[fr][to] {
+					edges = append(edges, graph.Edge{graph.NI(fr), to})
+				}
+				adj[fr][to] = int(to) != fr
+			}
+		}
+		if len(tn) != len(edges) {
+			t.Fatal("TrussNumbers", tn, "edges", edges)
+		}
+		for k := 2; ; k++ {
+			// k-truss by repeated removal
+			for removed := true; removed; {
+				removed = false
+				for _, e := range edges {
+					if !adj[e.N1][e.N2] {
+						continue
+					}
+					tri := 0
+					for w := range adj {
+						if adj[e.N1][w] && adj[e.N2][w] {
+							tri++
+						}
+					}
+					if tri < k-2 {
+						adj[e.N1][e.N2] = false
+						adj[e.N2][e.N1] = false
+						removed = true
+					}
+				}
+			}
+			left := 0
+			for _, e := range edges {
+				if adj[e.N1][e.N2] != (tn[e] >= k) {
+					t.Fatal("edge", e, "truss number", tn[e], "in", k, "truss:",
+						adj[e.N1][e.N2])
+				}
+				if adj[e.N1][e.N2] {
+					left++
+				}
+			}
+			// KTruss includes parallel edges
+			m := 0
+			for fr, to := range u.AdjacencyList {
+				for _, to := range to {
+					if graph.NI(fr) < to && adj[fr][to] {
+						m++
+					}
+				}
+			}
+			if sm := u.KTruss(k).Size(); sm != m {
+				t.Fatal("KTruss", k, "size", sm, "want", m)
+			}
+			if left == 0 {
+				break
+			}
+		}
+		lt := g.TrussNumbers()
+		for e, x := range lt {
+			if tn[e.Edge] != x {
+				t.Fatal("labeled", e, x, "want", tn[e.Edge])
+			}
+		}
+	}
+}
+
 /* shelved
 func ExampleBiconnectedComponents_Find() {
 	g := graph.AdjacencyList{
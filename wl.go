@@ -0,0 +1,209 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph
+
+// wl.go has Weisfeiler-Lehman color refinement.
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"sort"
+)
+
+// WeisfeilerLehman computes 1-dimensional Weisfeiler-Lehman color refinement
+// of a graph.
+//
+// Argument init gives initial node colors.  If init is nil, all nodes start
+// with color 0.  At each iteration, the new color of a node is a hash of its
+// current color, the multiset of colors of nodes at its out arcs, and the
+// multiset of colors of nodes at its in arcs.  For undirected graphs the two
+// multisets are the same.
+//
+// If argument iterations is non-negative, that many iterations are run.
+// If it is negative, iterations continue as long as they increase the number
+// of distinct colors, that is, until the coloring is stable.
+//
+// Returned colors has the coloring for each iteration, starting with the
+// initial coloring at colors[0].  Colors are 64 bit hashes independent of
+// node numbering, so colors can be compared across graphs.  Also returned is
+// a hash of the graph, computed from the multisets of colors of all
+// iterations.
+//
+// Isomorphic graphs have equal hashes for the same initial colors and number
+// of iterations.  Unequal hashes show graphs are not isomorphic but equal
+// hashes do not show graphs are isomorphic.
+//
+// Time complexity is O((V + E) log V) per iteration.
+//
+// See also WLSubtreeKernel.
+func (g AdjacencyList) WeisfeilerLehman(init []uint64, iterations int) (colors [][]uint64, hash uint64) {
+	t, _ := Directed{g}.Transpose()
+	ta := t.AdjacencyList
+	return wlRefine(len(g), init, iterations,
+		func(c []uint64, n NI, sig []uint64) []uint64 {
+			for _, to := range [][]NI{g[n], ta[n]} {
+				sig = append(sig, uint64(len(to)))
+				s := len(sig)
+				for _, to := range to {
+					sig = append(sig, c[to])
+				}
+				ms := sig[s:]
+				sort.Slice(ms, func(i, j int) bool { return ms[i] < ms[j] })
+			}
+			return sig
+		})
+}
+
+// WeisfeilerLehman computes 1-dimensional Weisfeiler-Lehman color refinement
+// of a labeled graph.
+//
+// If argument seedLabels is true, this is as described for
+// AdjacencyList.WeisfeilerLehman except that arc labels seed the initial
+// colors and are also hashed at each iteration.  If init is nil, the initial
+// color of a node is a hash of the multisets of labels of its out arcs and
+// its in arcs.  Otherwise init gives initial colors as usual.  At each
+// iteration, multisets are of pairs of label and node color.
+//
+// If seedLabels is false, labels are ignored and the result is that of
+// AdjacencyList.WeisfeilerLehman on the unlabeled graph.
+func (g LabeledAdjacencyList) WeisfeilerLehman(init []uint64, seedLabels bool, iterations int) (colors [][]uint64, hash uint64) {
+	if !seedLabels {
+		return g.Unlabeled().WeisfeilerLehman(init, iterations)
+	}
+	t, _ := LabeledDirected{g}.Transpose()
+	ta := t.LabeledAdjacencyList
+	if init == nil {
+		init = wlLabelColors(g, ta)
+	}
+	return wlRefine(len(g), init, iterations,
+		func(c []uint64, n NI, sig []uint64) []uint64 {
+			for _, to := range [][]Half{g[n], ta[n]} {
+				ps := make([][2]uint64, len(to))
+				for i, h := range to {
+					ps[i] = [2]uint64{uint64(h.Label), c[h.To]}
+				}
+				sort.Slice(ps, func(i, j int) bool {
+					return ps[i][0] < ps[j][0] ||
+						ps[i][0] == ps[j][0] && ps[i][1] < ps[j][1]
+				})
+				sig = append(sig, uint64(len(ps)))
+				for _, p := range ps {
+					sig = append(sig, p[0], p[1])
+				}
+			}
+			return sig
+		})
+}
+
+// wlLabelColors returns initial colors for a labeled graph g with transpose
+// ta.  The color of a node is a hash of the multisets of labels of its out
+// arcs and in arcs.
+func wlLabelColors(g, ta LabeledAdjacencyList) []uint64 {
+	c := make([]uint64, len(g))
+	h := fnv.New64a()
+	var b [8]byte
+	var s []uint64
+	for n := range g {
+		s = s[:0]
+		for _, to := range [][]Half{g[n], ta[n]} {
+			s = append(s, uint64(len(to)))
+			x := len(s)
+			for _, to := range to {
+				s = append(s, uint64(to.Label))
+			}
+			ms := s[x:]
+			sort.Slice(ms, func(i, j int) bool { return ms[i] < ms[j] })
+		}
+		h.Reset()
+		for _, x := range s {
+			binary.LittleEndian.PutUint64(b[:], x)
+			h.Write(b[:])
+		}
+		c[n] = h.Sum64()
+	}
+	return c
+}
+
+// wlRefine implements WeisfeilerLehman for a graph of order n.  Function
+// sig must append a signature of the neighborhood of node n under coloring c
+// to slice sig and return the result.
+func wlRefine(n int, init []uint64, iterations int, sig func(c []uint64, n NI, sig []uint64) []uint64) ([][]uint64, uint64) {
+	c := make([]uint64, n)
+	if init != nil {
+		copy(c, init)
+	}
+	colors := [][]uint64{c}
+	gh := fnv.New64a()
+	var b [8]byte
+	write := func(h hash.Hash64, x uint64) {
+		binary.LittleEndian.PutUint64(b[:], x)
+		h.Write(b[:])
+	}
+	hashColors := func(c []uint64) {
+		s := append([]uint64{}, c...)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+		write(gh, uint64(len(s)))
+		for _, x := range s {
+			write(gh, x)
+		}
+	}
+	hashColors(c)
+	distinct := func(c []uint64) int {
+		m := map[uint64]bool{}
+		for _, x := range c {
+			m[x] = true
+		}
+		return len(m)
+	}
+	nd := distinct(c)
+	var s []uint64
+	h := fnv.New64a()
+	for i := 0; iterations < 0 || i < iterations; i++ {
+		next := make([]uint64, n)
+		for v := range next {
+			s = sig(c, NI(v), append(s[:0], c[v]))
+			h.Reset()
+			for _, x := range s {
+				write(h, x)
+			}
+			next[v] = h.Sum64()
+		}
+		if iterations < 0 {
+			d := distinct(next)
+			if d == nd {
+				break
+			}
+			nd = d
+		}
+		c = next
+		colors = append(colors, c)
+		hashColors(c)
+	}
+	return colors, gh.Sum64()
+}
+
+// WLSubtreeKernel computes the Weisfeiler-Lehman subtree kernel of two
+// graphs.
+//
+// Arguments c1 and c2 are colorings of the two graphs as returned by
+// WeisfeilerLehman.  The kernel value is the number of pairs of nodes, one
+// from each graph, with equal colors, summed over iterations.  If c1 and c2
+// have different numbers of iterations, only iterations present in both are
+// summed.
+//
+// A similarity normalized to the range 0 to 1 is given by
+// k(g, h) / sqrt(k(g, g) * k(h, h)).
+func WLSubtreeKernel(c1, c2 [][]uint64) (k int) {
+	for i := 0; i < len(c1) && i < len(c2); i++ {
+		count := map[uint64]int{}
+		for _, x := range c1[i] {
+			count[x]++
+		}
+		for _, x := range c2[i] {
+			k += count[x]
+		}
+	}
+	return
+}
@@ -0,0 +1,217 @@
+// Copyright 2018 Sonia Keys
+// License MIT: http://opensource.org/licenses/MIT
+
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/soniakeys/graph"
+)
+
+func ExampleAdjacencyList_WeisfeilerLehman() {
+	// A hexagon, two triangles, and a path on six nodes.
+	var hex, tri, path graph.Undirected
+	for i := graph.NI(0); i < 6; i++ {
+		hex.AddEdge(i, (i+1)%6)
+	}
+	for i := graph.NI(0); i < 3; i++ {
+		tri.AddEdge(i, (i+1)%3)
+		tri.AddEdge(i+3, (i+1)%3+3)
+	}
+	for i := graph.NI(0); i < 5; i++ {
+		path.AddEdge(i, i+1)
+	}
+	hc, hh := hex.WeisfeilerLehman(nil, -1)
+	tc, th := tri.WeisfeilerLehman(nil, -1)
+	pc, ph := path.WeisfeilerLehman(nil, -1)
+	fmt.Println("iterations:", len(hc)-1, len(tc)-1, len(pc)-1)
+	// 1-WL does not distinguish regular graphs of the same degree.
+	fmt.Println("hexagon, triangles:", hh == th)
+	fmt.Println("hexagon, path:", hh == ph)
+	// colors are equal at symmetric nodes of the path
+	c := pc[len(pc)-1]
+	fmt.Println(c[0] == c[5], c[1] == c[4], c[2] == c[3], c[0] == c[1])
+	// Output:
+	// iterations: 0 0 2
+	// hexagon, triangles: true
+	// hexagon, path: false
+	// true true true false
+}
+
+func ExampleLabeledAdjacencyList_WeisfeilerLehman() {
+	// Two triangles with edge labels 1, 1, 2 and 1, 2, 2.
+	var g, h graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 1)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	g.AddEdge(graph.Edge{2, 0}, 2)
+	h.AddEdge(graph.Edge{0, 1}, 1)
+	h.AddEdge(graph.Edge{1, 2}, 2)
+	h.AddEdge(graph.Edge{2, 0}, 2)
+	_, gh := g.WeisfeilerLehman(nil, true, 1)
+	_, hh := h.WeisfeilerLehman(nil, true, 1)
+	_, uh := g.WeisfeilerLehman(nil, false, 1)
+	_, vh := h.WeisfeilerLehman(nil, false, 1)
+	fmt.Println("labeled equal:", gh == hh)
+	fmt.Println("unlabeled equal:", uh == vh)
+	// initial colors can distinguish node types.
+	_, ih := g.WeisfeilerLehman([]uint64{7, 0, 0}, false, 1)
+	fmt.Println("init equal:", ih == uh)
+	// Output:
+	// labeled equal: false
+	// unlabeled equal: true
+	// init equal: false
+}
+
+func ExampleWLSubtreeKernel() {
+	// A star, a path, and a path numbered differently.
+	star := graph.Undirected{graph.AdjacencyList{
+		0: {1, 2, 3},
+		1: {0},
+		2: {0},
+		3: {0},
+	}}
+	path := graph.Undirected{graph.AdjacencyList{
+		0: {1},
+		1: {0, 2},
+		2: {1, 3},
+		3: {2},
+	}}
+	path2 := graph.Undirected{graph.AdjacencyList{
+		0: {2},
+		1: {3},
+		2: {0, 3},
+		3: {2, 1},
+	}}
+	sc, _ := star.WeisfeilerLehman(nil, 2)
+	pc, _ := path.WeisfeilerLehman(nil, 2)
+	p2c, _ := path2.WeisfeilerLehman(nil, 2)
+	fmt.Println("star, star:", graph.WLSubtreeKernel(sc, sc))
+	fmt.Println("path, path:", graph.WLSubtreeKernel(pc, pc))
+	fmt.Println("path, path2:", graph.WLSubtreeKernel(pc, p2c))
+	fmt.Println("star, path:", graph.WLSubtreeKernel(sc, pc))
+	// Output:
+	// star, star: 36
+	// path, path: 32
+	// path, path2: 32
+	// star, path: 22
+}
+
+// TestWeisfeilerLehman checks that hashes and color multisets are invariant
+// under permutation and that stable colorings are equitable.
+func TestWeisfeilerLehman(t *testing.T) {
+	r := rand.New(rand.NewSource(29))
+	sorted := func(c []uint64) string {
+		s := append([]uint64{}, c...)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+		return fmt.Sprint(s)
+	}
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(15)
+		g := make(graph.LabeledAdjacencyList, n)
+		for a := r.Intn(3 * n); a > 0; a-- {
+			fr := r.Intn(n)
+			g[fr] = append(g[fr], graph.Half{graph.NI(r.Intn(n)),
+				graph.LI(r.Intn(3))})
+		}
+		init := make([]uint64, n)
+		for v := range init {
+			init[v] = uint64(r.Intn(2))
+		}
+		perm := r.Perm(n)
+		h, _ := g.Copy()
+		h.Permute(perm)
+		h.ShuffleArcLists(r)
+		hInit := make([]uint64, n)
+		for v, p := range perm {
+			hInit[p] = init[v]
+		}
+		gc, gh := g.WeisfeilerLehman(init, true, -1)
+		hc, hh := h.WeisfeilerLehman(hInit, true, -1)
+		if gh != hh || len(gc) != len(hc) {
+			t.Fatal("labeled hashes differ")
+		}
+		for it := range gc {
+			if sorted(gc[it]) != sorted(hc[it]) {
+				t.Fatal("labeled color multisets differ")
+			}
+			for v, p := range perm {
+				if gc[it][v] != hc[it][p] {
+					t.Fatal("labeled colors not permuted")
+				}
+			}
+		}
+		// unlabeled, stable coloring is equitable
+		u := g.Unlabeled()
+		uc, _ := u.WeisfeilerLehman(nil, -1)
+		c := uc[len(uc)-1]
+		tr, _ := graph.Directed{u}.Transpose()
+		sig := func(v int) string {
+			var out, in []uint64
+			for _, w := range u[v] {
+				out = append(out, c[w])
+			}
+			for _, w := range tr.AdjacencyList[v] {
+				in = append(in, c[w])
+			}
+			return sorted(out) + sorted(in)
+		}
+		for v := range c {
+			for w := range c {
+				if c[v] == c[w] && sig(v) != sig(w) {
+					t.Fatal("stable coloring not equitable")
+				}
+			}
+		}
+		if k, kp := graph.WLSubtreeKernel(gc, gc),
+			graph.WLSubtreeKernel(gc, hc); k != kp {
+			t.Fatal("kernel", kp, "want", k)
+		}
+	}
+}
+
+// TestWeisfeilerLehmanSeedLabels checks that labels seed initial colors in
+// one mode and are ignored in the other.
+func TestWeisfeilerLehmanSeedLabels(t *testing.T) {
+	// two paths 0-1-2, with labels 1, 1 and with labels 1, 2.
+	var g, h graph.LabeledUndirected
+	g.AddEdge(graph.Edge{0, 1}, 1)
+	g.AddEdge(graph.Edge{1, 2}, 1)
+	h.AddEdge(graph.Edge{0, 1}, 1)
+	h.AddEdge(graph.Edge{1, 2}, 2)
+	gs, gsh := g.WeisfeilerLehman(nil, true, -1)
+	hs, hsh := h.WeisfeilerLehman(nil, true, -1)
+	gu, guh := g.WeisfeilerLehman(nil, false, -1)
+	hu, huh := h.WeisfeilerLehman(nil, false, -1)
+	// seeded, ends of h differ from the start
+	if c := hs[0]; c[0] == c[2] || c[0] == c[1] {
+		t.Fatal("seeded initial colors", c)
+	}
+	if c := gs[0]; c[0] != c[2] || c[0] == c[1] {
+		t.Fatal("seeded initial colors", c)
+	}
+	if gsh == hsh {
+		t.Fatal("seeded hashes equal")
+	}
+	// unseeded, labels make no difference
+	if guh != huh || len(gu) != len(hu) {
+		t.Fatal("unseeded hashes differ")
+	}
+	for it := range gu {
+		if fmt.Sprint(gu[it]) != fmt.Sprint(hu[it]) {
+			t.Fatal("unseeded colors differ")
+		}
+	}
+	if c := hu[len(hu)-1]; c[0] != c[2] {
+		t.Fatal("unseeded stable colors", c)
+	}
+	if c := hu[0]; c[0] != 0 || c[1] != 0 || c[2] != 0 {
+		t.Fatal("unseeded initial colors", c)
+	}
+	if _, uh := h.Unlabeled().WeisfeilerLehman(nil, -1); uh != huh {
+		t.Fatal("unseeded hash", huh, "unlabeled", uh)
+	}
+}